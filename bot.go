@@ -4,12 +4,11 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"math"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/JasonKhew96/telegram-search-bot-go/models"
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
@@ -21,20 +20,36 @@ type SearchBot struct {
 	bot        *gotgbot.Bot
 	loc        *time.Location
 	adminCache map[int64][]gotgbot.ChatMember
+	countCache map[string]searchCount
 }
 
-func StartBot(databaseFile, configFile string) {
-	loc, err := time.LoadLocation("Asia/Taipei")
+// searchCount is a cached result count of an inline query, counting is capped at searchCountLimit
+type searchCount struct {
+	count   int
+	capped  bool
+	expires time.Time
+}
+
+const (
+	searchCountLimit = 1000
+	searchCountTTL   = 5 * time.Minute
+)
+
+func StartBot(databaseFile, configFile, dictionaryFile string) {
+	config, err := ParseConfig(configFile)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	config, err := ParseConfig(configFile)
+	if config.Timezone == "" {
+		config.Timezone = "Asia/Taipei"
+	}
+	loc, err := time.LoadLocation(config.Timezone)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	database, err := NewDatabase(databaseFile, false)
+	database, err := NewDatabase(databaseFile, dictionaryFile, false)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -70,6 +85,7 @@ func StartBot(databaseFile, configFile string) {
 		bot:        bot,
 		loc:        loc,
 		adminCache: make(map[int64][]gotgbot.ChatMember),
+		countCache: make(map[string]searchCount),
 	}
 
 	dispatcher := ext.NewDispatcher(&ext.DispatcherOpts{
@@ -82,10 +98,18 @@ func StartBot(databaseFile, configFile string) {
 	updater := ext.NewUpdater(dispatcher, nil)
 
 	dispatcher.AddHandler(handlers.NewCommand("dlog", m.commandDeleteResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("undlog", m.commandRestoreResponse).SetTriggers([]rune("/!")))
 	dispatcher.AddHandler(handlers.NewCommand("start", m.commandStartStopResponse).SetTriggers([]rune("/!")))
 	dispatcher.AddHandler(handlers.NewCommand("stop", m.commandStartStopResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("sort", m.commandSortResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("timezone", m.commandTimezoneResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("goto", m.commandGotoResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("history", m.commandHistoryResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("thread", m.commandThreadResponse).SetTriggers([]rune("/!")))
+	dispatcher.AddHandler(handlers.NewCommand("tags", m.commandTagsResponse).SetTriggers([]rune("/!")))
 	dispatcher.AddHandler(handlers.NewChatMember(m.chatMemberRequest, m.chatMemberResponse))
 	dispatcher.AddHandler(handlers.NewInlineQuery(m.inlineQueryRequest, m.inlineQueryResponse))
+	dispatcher.AddHandler(handlers.NewMessage(m.forumTopicRequest, m.forumTopicResponse))
 	dispatcher.AddHandler(handlers.NewMessage(m.newMessageRequest, m.newMessageResponse).SetAllowChannel(true).SetAllowEdited(true))
 
 	err = updater.StartPolling(bot, &ext.PollingOpts{
@@ -100,6 +124,9 @@ func StartBot(databaseFile, configFile string) {
 		log.Fatalln(err)
 	}
 	log.Printf("Bot started as %s\n", bot.User.Username)
+	if config.PurgeGracePeriod > 0 {
+		go m.purgeDeletedMessages()
+	}
 	updater.Idle()
 }
 
@@ -128,21 +155,14 @@ func (m *SearchBot) commandDeleteResponse(b *gotgbot.Bot, ctx *ext.Context) erro
 		return nil
 	}
 
-	re, err := regexp.Compile(`https://t\.me/c/(\d+)/(\d+)`)
+	chatId, msgId, ok, err := m.resolveTelegramLink(ctx.EffectiveMessage.GetText())
 	if err != nil {
 		return err
 	}
-	matches := re.FindStringSubmatch(ctx.EffectiveMessage.GetText())
-	if len(matches) != 3 {
+	if !ok {
 		return nil
 	}
 
-	chatId, err := strconv.ParseInt(matches[1], 10, 64)
-	if err != nil {
-		return err
-	}
-	chatId = convert2BotChatId(chatId)
-
 	if chatId != ctx.EffectiveChat.Id {
 		return nil
 	}
@@ -155,11 +175,6 @@ func (m *SearchBot) commandDeleteResponse(b *gotgbot.Bot, ctx *ext.Context) erro
 		return nil
 	}
 
-	msgId, err := strconv.ParseInt(matches[2], 10, 64)
-	if err != nil {
-		return err
-	}
-
 	admins, err := m.GetChatAdministrators(chatId)
 	if err != nil {
 		return err
@@ -193,7 +208,7 @@ func (m *SearchBot) commandDeleteResponse(b *gotgbot.Bot, ctx *ext.Context) erro
 		return nil
 	}
 
-	if err := m.db.DeleteMessage(chatId, msgId); err != nil {
+	if err := m.db.DeleteMessage(chatId, msgId, ctx.EffectiveSender.Id()); err != nil {
 		return err
 	}
 
@@ -206,7 +221,7 @@ func (m *SearchBot) commandDeleteResponse(b *gotgbot.Bot, ctx *ext.Context) erro
 	return nil
 }
 
-func (m *SearchBot) commandStartStopResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+func (m *SearchBot) commandRestoreResponse(b *gotgbot.Bot, ctx *ext.Context) error {
 	if ctx.EffectiveChat.Type == "private" {
 		return nil
 	}
@@ -214,6 +229,89 @@ func (m *SearchBot) commandStartStopResponse(b *gotgbot.Bot, ctx *ext.Context) e
 		return nil
 	}
 
+	chat, err := m.db.GetChat(ctx.EffectiveChat.Id)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	chatId, msgId, ok, err := m.resolveTelegramLink(ctx.EffectiveMessage.GetText())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	if chatId != ctx.EffectiveChat.Id {
+		return nil
+	}
+
+	isEffectiveUserAdmin := false
+	admins, err := m.GetChatAdministrators(chatId)
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		if admin.GetUser().Id == ctx.EffectiveSender.Id() {
+			isEffectiveUserAdmin = true
+			break
+		}
+	}
+	if !isEffectiveUserAdmin {
+		return nil
+	}
+
+	_, err = m.db.GetDeletedMessage(chatId, msgId)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows {
+		msg, err := ctx.EffectiveMessage.Reply(b, "Deleted message not found", nil)
+		if err != nil {
+			return err
+		}
+		time.AfterFunc(10*time.Second, m.deleteMsg(chatId, msg.MessageId))
+		return nil
+	}
+
+	if err := m.db.RestoreMessage(chatId, msgId); err != nil {
+		return err
+	}
+
+	restoredMessage, err := ctx.EffectiveMessage.Reply(b, "Message restored", nil)
+	if err != nil {
+		return err
+	}
+	time.AfterFunc(10*time.Second, m.deleteMsg(chatId, restoredMessage.MessageId))
+
+	return nil
+}
+
+// purgeDeletedMessages periodically removes messages deleted longer than the grace period ago
+func (m *SearchBot) purgeDeletedMessages() {
+	for ; ; time.Sleep(time.Hour) {
+		count, err := m.db.PurgeDeletedMessages(time.Now().Add(-m.config.PurgeGracePeriod))
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if count > 0 {
+			log.Printf("purged %d deleted messages", count)
+		}
+	}
+}
+
+func (m *SearchBot) commandStartStopResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveChat.Type == "private" {
+		return m.startPayloadResponse(b, ctx)
+	}
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
 	isStart := strings.HasPrefix(ctx.EffectiveMessage.GetText()[1:], "start")
 
 	isEffectiveUserAdmin := false
@@ -240,6 +338,9 @@ func (m *SearchBot) commandStartStopResponse(b *gotgbot.Bot, ctx *ext.Context) e
 		if err := m.db.UpsertChat(ctx.EffectiveChat.Id, ctx.EffectiveChat.Title, isStart); err != nil {
 			return err
 		}
+		if err := m.db.UpdateChatProfile(ctx.EffectiveChat.Id, ctx.EffectiveChat.Title, ctx.EffectiveChat.Username); err != nil {
+			return err
+		}
 		text := "This chat is enabled"
 		if !isStart {
 			text = "This chat is disabled"
@@ -253,6 +354,9 @@ func (m *SearchBot) commandStartStopResponse(b *gotgbot.Bot, ctx *ext.Context) e
 			return err
 		}
 	}
+	if err := m.db.UpdateChatProfile(ctx.EffectiveChat.Id, ctx.EffectiveChat.Title, ctx.EffectiveChat.Username); err != nil {
+		return err
+	}
 
 	var text string
 	if chat.Enabled {
@@ -272,6 +376,496 @@ func (m *SearchBot) commandStartStopResponse(b *gotgbot.Bot, ctx *ext.Context) e
 	return err
 }
 
+func (m *SearchBot) commandSortResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveChat.Type == "private" {
+		return nil
+	}
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
+	chat, err := m.db.GetChat(ctx.EffectiveChat.Id)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	isEffectiveUserAdmin := false
+	admins, err := m.GetChatAdministrators(ctx.EffectiveChat.Id)
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		if admin.GetUser().Id == ctx.EffectiveSender.Id() {
+			isEffectiveUserAdmin = true
+			break
+		}
+	}
+	if !isEffectiveUserAdmin {
+		return nil
+	}
+
+	args := ctx.Args()
+	if len(args) < 2 {
+		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Current sort order is %s\nUsage: /sort %s|%s", chat.SortOrder, SortOrderTime, SortOrderRelevance), nil)
+		return err
+	}
+	sortOrder := strings.ToLower(args[1])
+	if sortOrder != SortOrderTime && sortOrder != SortOrderRelevance {
+		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Unknown sort order, use %s or %s", SortOrderTime, SortOrderRelevance), nil)
+		return err
+	}
+
+	if err := m.db.UpdateChatSortOrder(ctx.EffectiveChat.Id, sortOrder); err != nil {
+		return err
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Default sort order is now %s", sortOrder), nil)
+	return err
+}
+
+// contextSize is the number of messages shown before and after a message for its context
+const contextSize = 5
+
+// contextPayload returns the start parameter of a deep link to the context of a message
+func contextPayload(chatId, msgId int64) string {
+	return fmt.Sprintf("ctx_%d_%d", chatId, msgId)
+}
+
+// parseContextPayload returns the chat and message id of a start parameter made by contextPayload
+func parseContextPayload(payload string) (chatId int64, msgId int64, ok bool) {
+	chat, msg, found := strings.Cut(strings.TrimPrefix(payload, "ctx_"), "_")
+	if !found || !strings.HasPrefix(payload, "ctx_") {
+		return 0, 0, false
+	}
+	chatId, err := strconv.ParseInt(chat, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	msgId, err = strconv.ParseInt(msg, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return chatId, msgId, true
+}
+
+// contextMarkup returns a keyboard with a button opening the context of a result in private
+func contextMarkup(botUsername string, chatId, msgId int64) *gotgbot.InlineKeyboardMarkup {
+	return &gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{{
+			Text: "Context",
+			Url:  fmt.Sprintf("https://t.me/%s?start=%s", botUsername, contextPayload(chatId, msgId)),
+		}}},
+	}
+}
+
+// startPayloadResponse handles deep links opened in private, currently the context of a message
+func (m *SearchBot) startPayloadResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+	args := strings.Fields(ctx.EffectiveMessage.GetText())
+	if len(args) < 2 {
+		return nil
+	}
+	chatId, msgId, ok := parseContextPayload(args[1])
+	if !ok {
+		return nil
+	}
+
+	chat, err := m.db.GetChat(chatId)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	chatPeer, err := m.db.GetChatPeerCount(chatId, ctx.EffectiveSender.Id())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || chatPeer <= 0 {
+		return nil
+	}
+
+	messages, err := m.db.GetMessageContext(chatId, msgId, contextSize)
+	if err != nil {
+		return err
+	}
+	if len(messages) <= 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, "Message not found", nil)
+		return err
+	}
+	loc := m.location(chat.Timezone)
+	peer, err := m.db.GetPeer(ctx.EffectiveSender.Id())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && peer.Timezone != "" {
+		loc = m.location(peer.Timezone)
+	}
+	text, entities, err := formatConversation(messages, msgId, loc)
+	if err != nil {
+		return err
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{
+		Entities: entities,
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+			IsDisabled: true,
+		},
+	})
+	return err
+}
+
+// resolveTelegramLink returns the bot chat id and message id of the first message link in text,
+// links of public chats are resolved through the stored chat usernames
+func (m *SearchBot) resolveTelegramLink(text string) (chatId int64, msgId int64, ok bool, err error) {
+	chatId, username, msgId, ok := parseTelegramLink(text)
+	if !ok || username == "" {
+		return chatId, msgId, ok, nil
+	}
+	chat, err := m.db.GetChatByUsername(username)
+	if err == sql.ErrNoRows {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return chat.ID, msgId, true, nil
+}
+
+// location returns the time zone shared by all timezones, the configured one is used
+// when they differ or are unset
+func (m *SearchBot) location(timezones ...string) *time.Location {
+	if len(timezones) <= 0 || timezones[0] == "" {
+		return m.loc
+	}
+	for _, timezone := range timezones[1:] {
+		if timezone != timezones[0] {
+			return m.loc
+		}
+	}
+	loc, err := time.LoadLocation(timezones[0])
+	if err != nil {
+		log.Println(err)
+		return m.loc
+	}
+	return loc
+}
+
+func (m *SearchBot) commandTimezoneResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
+	// private chats set the time zone of the user, groups the one of the chat
+	var current string
+	if ctx.EffectiveChat.Type == "private" {
+		peer, err := m.db.GetPeer(ctx.EffectiveSender.Id())
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		current = peer.Timezone
+	} else {
+		chat, err := m.db.GetChat(ctx.EffectiveChat.Id)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == sql.ErrNoRows || !chat.Enabled {
+			return nil
+		}
+
+		isEffectiveUserAdmin := false
+		admins, err := m.GetChatAdministrators(ctx.EffectiveChat.Id)
+		if err != nil {
+			return err
+		}
+		for _, admin := range admins {
+			if admin.GetUser().Id == ctx.EffectiveSender.Id() {
+				isEffectiveUserAdmin = true
+				break
+			}
+		}
+		if !isEffectiveUserAdmin {
+			return nil
+		}
+		current = chat.Timezone
+	}
+
+	args := ctx.Args()
+	if len(args) < 2 {
+		_, err := ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Current time zone is %s\nUsage: /timezone Asia/Taipei|default", m.location(current)), nil)
+		return err
+	}
+	timezone := args[1]
+	if strings.EqualFold(timezone, "default") {
+		timezone = ""
+	} else if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		_, err = ctx.EffectiveMessage.Reply(b, "Unknown time zone, use a name like Asia/Taipei", nil)
+		return err
+	}
+
+	var err error
+	if ctx.EffectiveChat.Type == "private" {
+		err = m.db.UpdatePeerTimezone(ctx.EffectiveSender.Id(), timezone)
+	} else {
+		err = m.db.UpdateChatTimezone(ctx.EffectiveChat.Id, timezone)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Time zone is now %s", m.location(timezone)), nil)
+	return err
+}
+
+func (m *SearchBot) commandGotoResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveChat.Type == "private" {
+		return nil
+	}
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
+	chat, err := m.db.GetChat(ctx.EffectiveChat.Id)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	chatPeer, err := m.db.GetChatPeerCount(ctx.EffectiveChat.Id, ctx.EffectiveSender.Id())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || chatPeer <= 0 {
+		return nil
+	}
+
+	args := ctx.Args()
+	if len(args) < 2 {
+		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Usage: /goto %s", time.DateOnly), nil)
+		return err
+	}
+	day, err := time.ParseInLocation(time.DateOnly, args[1], m.location(chat.Timezone))
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Invalid date, use %s", time.DateOnly), nil)
+		return err
+	}
+
+	msg, err := m.db.GetFirstMessageSince(ctx.EffectiveChat.Id, day)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !msg.Timestamp.Before(day.AddDate(0, 0, 1)) {
+		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("No messages on %s", day.Format(time.DateOnly)), nil)
+		return err
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, generateTelegramLink(chat.Username, msg.ChatID, msg.MessageThreadID.Int64, msg.MSGID), &gotgbot.SendMessageOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{
+			InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{{
+				Text: fmt.Sprintf("Go to %s", day.Format(time.DateOnly)),
+				Url:  generateTelegramLink(chat.Username, msg.ChatID, msg.MessageThreadID.Int64, msg.MSGID),
+			}}},
+		},
+	})
+	return err
+}
+
+// commandHistoryResponse replies with the edit history of a linked message, it works in the chat
+// itself and in private for members of the chat
+func (m *SearchBot) commandHistoryResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
+	chatId, msgId, ok, err := m.resolveTelegramLink(ctx.EffectiveMessage.GetText())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		_, err := ctx.EffectiveMessage.Reply(b, "Usage: /history https://t.me/c/1234567890/123 or https://t.me/username/123", nil)
+		return err
+	}
+	if ctx.EffectiveChat.Type != "private" && chatId != ctx.EffectiveChat.Id {
+		return nil
+	}
+
+	chat, err := m.db.GetChat(chatId)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	chatPeer, err := m.db.GetChatPeerCount(chatId, ctx.EffectiveSender.Id())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || chatPeer <= 0 {
+		return nil
+	}
+
+	msg, err := m.db.GetMessage(chatId, msgId)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows {
+		_, err = ctx.EffectiveMessage.Reply(b, "Message not found", nil)
+		return err
+	}
+	revisions, err := m.db.GetMessageRevisions(chatId, msgId)
+	if err != nil {
+		return err
+	}
+	peer, err := m.db.GetPeer(msg.FromID)
+	if err != nil {
+		return err
+	}
+	if len(revisions) <= 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, "This message has not been edited", nil)
+		return err
+	}
+
+	// the newest versions are kept if the history does not fit into a single message
+	loc := m.location(chat.Timezone)
+	var sections []string
+	length := 0
+	for i := len(revisions) - 1; i >= 0; i-- {
+		text, _, err := snippetAround(revisions[i].Text, nil, 512)
+		if err != nil {
+			return err
+		}
+		section := fmt.Sprintf("*%s*\n%s", escapeMarkdownV2(revisions[i].EditDate.In(loc).Format(time.DateTime)), text2ExpandableQuote(text))
+		if length+len(section) > 3072 {
+			break
+		}
+		length += len(section)
+		sections = append([]string{section}, sections...)
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, text2Via(strings.Join(sections, "\n"), chat.Username, msg.ChatID, msg.MessageThreadID.Int64, msg.MSGID, peer.FullName), &gotgbot.SendMessageOpts{
+		ParseMode: "MarkdownV2",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+			IsDisabled: true,
+		},
+	})
+	return err
+}
+
+// threadLimit is the number of messages of a reply chain shown by /thread
+const threadLimit = 20
+
+// commandThreadResponse shows the reply chain of a message linked to or replied to
+func (m *SearchBot) commandThreadResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
+	chatId, msgId, ok, err := m.resolveTelegramLink(ctx.EffectiveMessage.GetText())
+	if err != nil {
+		return err
+	}
+	// messages in forum topics reply to the topic creation message unless they are real replies
+	if reply := ctx.EffectiveMessage.ReplyToMessage; !ok && reply != nil && reply.ForumTopicCreated == nil && ctx.EffectiveChat.Type != "private" {
+		chatId, msgId, ok = ctx.EffectiveChat.Id, reply.MessageId, true
+	}
+	if !ok {
+		_, err := ctx.EffectiveMessage.Reply(b, "Usage: /thread https://t.me/c/1234567890/123, https://t.me/username/123 or reply to a message", nil)
+		return err
+	}
+	if ctx.EffectiveChat.Type != "private" && chatId != ctx.EffectiveChat.Id {
+		return nil
+	}
+
+	chat, err := m.db.GetChat(chatId)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	chatPeer, err := m.db.GetChatPeerCount(chatId, ctx.EffectiveSender.Id())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || chatPeer <= 0 {
+		return nil
+	}
+
+	chain, err := m.db.GetReplyChain(chatId, msgId, threadLimit)
+	if err != nil {
+		return err
+	}
+	if len(chain) <= 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, "Message not found", nil)
+		return err
+	}
+	text, entities, err := formatConversation(chain, msgId, m.location(chat.Timezone))
+	if err != nil {
+		return err
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{
+		Entities: entities,
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+			IsDisabled: true,
+		},
+	})
+	return err
+}
+
+func (m *SearchBot) commandTagsResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveChat.Type == "private" {
+		return nil
+	}
+	if ctx.EffectiveSender.User == nil {
+		return nil
+	}
+
+	chat, err := m.db.GetChat(ctx.EffectiveChat.Id)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || !chat.Enabled {
+		return nil
+	}
+
+	chatPeer, err := m.db.GetChatPeerCount(ctx.EffectiveChat.Id, ctx.EffectiveSender.Id())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || chatPeer <= 0 {
+		return nil
+	}
+
+	limit := 20
+	if args := ctx.Args(); len(args) >= 2 {
+		if n, err := strconv.Atoi(args[1]); err == nil && n > 0 && n <= 100 {
+			limit = n
+		}
+	}
+	hashtags, err := m.db.GetTopHashtags(ctx.EffectiveChat.Id, limit)
+	if err != nil {
+		return err
+	}
+	if len(hashtags) <= 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, "No hashtags found", nil)
+		return err
+	}
+	var lines []string
+	for _, hashtag := range hashtags {
+		lines = append(lines, fmt.Sprintf("#%s %d", hashtag.Tag, hashtag.Count))
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, strings.Join(lines, "\n"), nil)
+	return err
+}
+
 func isAdmin(status string) bool {
 	return status == "administrator" || status == "creator"
 }
@@ -377,49 +971,67 @@ func (m *SearchBot) inlineQueryResponse(b *gotgbot.Bot, ctx *ext.Context) error
 		chatIds = append(chatIds, chatPeer.ChatID)
 	}
 
-	// 2
-	// text
-	// text 2
-	// @username text
-	// @username text 2
-	// @114514 text
-	// @114514 text 2
-	splits := strings.Split(ctx.InlineQuery.Query, " ")
-	peerId := int64(0)
-	username := ""
-	queries := []string{}
-	page := 1
-
-	if strings.HasPrefix(splits[0], "@") {
-		peerId, err = strconv.ParseInt(splits[0][1:], 10, 64)
-		if err != nil {
-			username = splits[0][1:]
+	chats, err := m.db.GetChats(chatIds)
+	if err != nil {
+		return err
+	}
+	peer, err := m.db.GetPeer(ctx.InlineQuery.From.Id)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	// dates are interpreted in the time zone of the user, or of the searched chats if they agree on one
+	var loc *time.Location
+	if peer != nil && peer.Timezone != "" {
+		loc = m.location(peer.Timezone)
+	} else {
+		var timezones []string
+		for _, chat := range chats {
+			timezones = append(timezones, chat.Timezone)
 		}
+		loc = m.location(timezones...)
 	}
-	n, err := strconv.Atoi(splits[len(splits)-1])
-	if err == nil && n > 1 {
-		page = n
+
+	query, err := ParseSearchQuery(ctx.InlineQuery.Query, loc)
+	if err != nil {
+		_, err = ctx.InlineQuery.Answer(b, []gotgbot.InlineQueryResult{gotgbot.InlineQueryResultArticle{
+			Id:    "info",
+			Title: "Invalid search query",
+			InputMessageContent: gotgbot.InputTextMessageContent{
+				MessageText: ".",
+			},
+			Description: err.Error(),
+		}}, &gotgbot.AnswerInlineQueryOpts{
+			CacheTime:  300,
+			IsPersonal: true,
+		})
+		return err
 	}
-	maxIndex := len(splits)
-	if page > 1 {
-		maxIndex -= 1
+	if query.SortOrder == "" {
+		query.SortOrder = defaultSortOrder(chats)
 	}
-	for i := 0; i < maxIndex; i++ {
-		q := splits[i]
-		if strings.HasPrefix(q, "@") {
-			continue
-		}
-		if q == "" {
-			continue
-		}
-		queries = append(queries, q)
+	cursor, err := ParseSearchCursor(ctx.InlineQuery.Offset)
+	if err != nil {
+		// answering with the first page again would append duplicates to the loaded results
+		log.Println(err)
+		_, err = ctx.InlineQuery.Answer(b, []gotgbot.InlineQueryResult{}, &gotgbot.AnswerInlineQueryOpts{
+			CacheTime:  15,
+			IsPersonal: true,
+		})
+		return err
 	}
 
-	messageAndPeers, err := m.db.SearchMessages(chatIds, username, peerId, queries, (page-1)*49)
+	messageAndPeers, nextCursor, err := m.db.SearchMessages(chatIds, query, cursor)
 	if err != nil {
 		return err
 	}
 
+	if len(messageAndPeers) <= 0 && cursor != nil {
+		_, err = ctx.InlineQuery.Answer(b, []gotgbot.InlineQueryResult{}, &gotgbot.AnswerInlineQueryOpts{
+			CacheTime:  15,
+			IsPersonal: true,
+		})
+		return err
+	}
 	if len(messageAndPeers) <= 0 {
 		_, err = ctx.InlineQuery.Answer(b, []gotgbot.InlineQueryResult{gotgbot.InlineQueryResultArticle{
 			Id:    "info",
@@ -435,34 +1047,61 @@ func (m *SearchBot) inlineQueryResponse(b *gotgbot.Bot, ctx *ext.Context) error
 		return err
 	}
 
-	results := []gotgbot.InlineQueryResult{
-		gotgbot.InlineQueryResultArticle{
+	// more results are loaded by the client through next_offset, so the total is only shown on the first page
+	results := []gotgbot.InlineQueryResult{}
+	if cursor == nil {
+		total := searchCount{count: len(messageAndPeers)}
+		if nextCursor != nil {
+			total, err = m.countMessages(ctx.InlineQuery.From.Id, ctx.InlineQuery.Query, chatIds, query)
+			if err != nil {
+				return err
+			}
+		}
+		title := fmt.Sprintf("Total %d", total.count)
+		if total.capped {
+			title = fmt.Sprintf("Total %d+", total.count)
+		}
+		results = append(results, gotgbot.InlineQueryResultArticle{
 			Id:    "info",
-			Title: fmt.Sprintf("Total %d Page: %d / %d", messageAndPeers[0].TotalCount, page, int64(math.Ceil(float64(messageAndPeers[0].TotalCount)/49))),
+			Title: title,
 			InputMessageContent: gotgbot.InputTextMessageContent{
 				MessageText: ".",
 			},
-		},
+		})
 	}
 
+	terms := query.Terms()
 	for _, mnp := range messageAndPeers {
-		title, err := trimUnicodeAddEllipsis(mnp.Text, 64)
+		text := mnp.DisplayText()
+		ranges := m.db.matchRanges(text, terms)
+		title, titleRanges, err := snippetAround(text, ranges, 64)
 		if err != nil {
 			log.Println(err)
 			continue
 		}
-		expandableQuote, err := trimUnicodeAddEllipsis(mnp.Text, 2048)
+		// leave room for the via line within the 4096 characters of a message
+		quote, quoteEntities, err := formatResult(mnp, text, ranges, 3840, true)
 		if err != nil {
 			log.Println(err)
 			continue
 		}
+		description := fmt.Sprintf("%s %s@%s", mnp.Timestamp.In(loc).Format(time.DateTime), mnp.FullName, mnp.Title)
+		if mnp.TopicName.Valid {
+			description += " › " + mnp.TopicName.String
+		}
+		markup := contextMarkup(b.User.Username, mnp.Message.ChatID, mnp.MSGID)
+		if result := cachedMediaResult(mnp, highlightPlain(title, titleRanges), description, ranges, markup); result != nil {
+			results = append(results, result)
+			continue
+		}
 		results = append(results, gotgbot.InlineQueryResultArticle{
-			Id:          strconv.FormatInt(mnp.MSGID, 10),
-			Title:       title,
-			Description: fmt.Sprintf("%s %s@%s", mnp.Timestamp.In(m.loc).Format(time.DateTime), mnp.FullName, mnp.Title),
+			Id:          mnp.Message.ID,
+			Title:       highlightPlain(title, titleRanges),
+			Description: description,
+			ReplyMarkup: markup,
 			InputMessageContent: gotgbot.InputTextMessageContent{
-				MessageText: text2Via(text2ExpandableQuote(expandableQuote), mnp.Message.ChatID, mnp.MSGID, mnp.FullName),
-				ParseMode:   "MarkdownV2",
+				MessageText: quote,
+				Entities:    quoteEntities,
 				LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
 					IsDisabled: true,
 				},
@@ -470,13 +1109,136 @@ func (m *SearchBot) inlineQueryResponse(b *gotgbot.Bot, ctx *ext.Context) error
 		})
 	}
 
+	nextOffset := ""
+	if nextCursor != nil {
+		nextOffset = nextCursor.String()
+	}
 	_, err = ctx.InlineQuery.Answer(b, results, &gotgbot.AnswerInlineQueryOpts{
 		CacheTime:  15,
 		IsPersonal: true,
+		NextOffset: nextOffset,
 	})
 	return err
 }
 
+// cachedMediaResult returns a result sending the stored file of a hit with its caption and the via link,
+// or nil if the media can not be sent again, e.g. because it was imported without a file id
+func cachedMediaResult(mnp *MessageAndPeer, title, description string, ranges []matchRange, markup *gotgbot.InlineKeyboardMarkup) gotgbot.InlineQueryResult {
+	if !mnp.FileID.Valid || mnp.FileID.String == "" {
+		return nil
+	}
+	// ranges of media without a caption refer to its label
+	if mnp.Text == "" {
+		ranges = nil
+	}
+	// captions are limited to 1024 characters
+	caption, captionEntities, err := formatResult(mnp, mnp.Text, ranges, 896, false)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	switch mnp.MediaType.String {
+	case MediaPhoto:
+		return gotgbot.InlineQueryResultCachedPhoto{
+			Id:              mnp.Message.ID,
+			PhotoFileId:     mnp.FileID.String,
+			Title:           title,
+			Description:     description,
+			Caption:         caption,
+			CaptionEntities: captionEntities,
+			ReplyMarkup:     markup,
+		}
+	case MediaDocument:
+		return gotgbot.InlineQueryResultCachedDocument{
+			Id:              mnp.Message.ID,
+			Title:           title,
+			DocumentFileId:  mnp.FileID.String,
+			Description:     description,
+			Caption:         caption,
+			CaptionEntities: captionEntities,
+			ReplyMarkup:     markup,
+		}
+	case MediaVoice:
+		return gotgbot.InlineQueryResultCachedVoice{
+			Id:              mnp.Message.ID,
+			VoiceFileId:     mnp.FileID.String,
+			Title:           title,
+			Caption:         caption,
+			CaptionEntities: captionEntities,
+			ReplyMarkup:     markup,
+		}
+	case MediaSticker:
+		// stickers can not have a caption
+		return gotgbot.InlineQueryResultCachedSticker{
+			Id:            mnp.Message.ID,
+			StickerFileId: mnp.FileID.String,
+			ReplyMarkup:   markup,
+		}
+	}
+	return nil
+}
+
+// countMessages returns the capped result count of an inline query, cached per user and query text
+// so that retyping or reopening a query does not count the matches again
+func (m *SearchBot) countMessages(peerId int64, text string, chatIds []int64, query *SearchQuery) (searchCount, error) {
+	now := time.Now()
+	key := strconv.FormatInt(peerId, 10) + ":" + strings.TrimSpace(text)
+	if total, ok := m.countCache[key]; ok && now.Before(total.expires) {
+		return total, nil
+	}
+	count, capped, err := m.db.CountMessages(chatIds, query, searchCountLimit)
+	if err != nil {
+		return searchCount{}, err
+	}
+	for k, v := range m.countCache {
+		if now.After(v.expires) {
+			delete(m.countCache, k)
+		}
+	}
+	total := searchCount{count: count, capped: capped, expires: now.Add(searchCountTTL)}
+	m.countCache[key] = total
+	return total, nil
+}
+
+// defaultSortOrder returns relevance only if every searched chat defaults to it
+func defaultSortOrder(chats []*models.Chat) string {
+	if len(chats) <= 0 {
+		return SortOrderTime
+	}
+	for _, chat := range chats {
+		if chat.SortOrder != SortOrderRelevance {
+			return SortOrderTime
+		}
+	}
+	return SortOrderRelevance
+}
+
+// forumTopicRequest matches service messages creating or renaming forum topics of enabled chats
+func (m *SearchBot) forumTopicRequest(msg *gotgbot.Message) bool {
+	if msg.ForumTopicCreated == nil && (msg.ForumTopicEdited == nil || msg.ForumTopicEdited.Name == "") {
+		return false
+	}
+	chat, err := m.db.GetChat(msg.Chat.Id)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return false
+	}
+	if err == sql.ErrNoRows {
+		return false
+	}
+	return chat.Enabled
+}
+
+func (m *SearchBot) forumTopicResponse(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	if msg.ForumTopicCreated != nil {
+		// the message creating a topic starts its thread
+		return m.db.UpsertForumTopic(msg.Chat.Id, msg.MessageId, msg.ForumTopicCreated.Name)
+	}
+	return m.db.UpsertForumTopic(msg.Chat.Id, msg.MessageThreadId, msg.ForumTopicEdited.Name)
+}
+
 func (m *SearchBot) newMessageRequest(msg *gotgbot.Message) bool {
 	if msg.Chat.Type == "private" {
 		return false
@@ -484,7 +1246,7 @@ func (m *SearchBot) newMessageRequest(msg *gotgbot.Message) bool {
 	if msg.ViaBot != nil && msg.ViaBot.Id == m.bot.Id {
 		return false
 	}
-	if msg.GetText() == "" {
+	if msg.GetText() == "" && mediaFromMessage(msg) == nil {
 		return false
 	}
 	chat, err := m.db.GetChat(msg.Chat.Id)
@@ -505,15 +1267,105 @@ func (m *SearchBot) newMessageResponse(b *gotgbot.Bot, ctx *ext.Context) error {
 	if err := m.db.InsertChatPeer(ctx.EffectiveChat.Id, ctx.EffectiveSender.Id()); err != nil {
 		return err
 	}
+	if err := m.db.UpdateChatProfile(ctx.EffectiveChat.Id, ctx.EffectiveChat.Title, ctx.EffectiveChat.Username); err != nil {
+		return err
+	}
 
+	text := ctx.EffectiveMessage.GetText()
 	if ctx.EditedMessage != nil {
-		if ctx.EditedMessage.EditDate-ctx.EditedMessage.Date > 2*24*60*60 { // 48 hours
-			return nil
+		if err := m.db.InsertMessageRevision(ctx.EffectiveChat.Id, ctx.EffectiveMessage.MessageId, text, ctx.EditedMessage.EditDate); err != nil {
+			return err
 		}
 	}
+	formatting := ctx.EffectiveMessage.Entities
+	if ctx.EffectiveMessage.Text == "" {
+		formatting = ctx.EffectiveMessage.CaptionEntities
+	}
+	return m.db.UpsertMessage(ctx.EffectiveChat.Id, ctx.EffectiveSender.Id(), ctx.EffectiveMessage.MessageId, text, encodeFormatting(formatting), ctx.EffectiveMessage.Date, relationsFromMessage(ctx.EffectiveMessage), mediaFromMessage(ctx.EffectiveMessage), entitiesFromMessage(ctx.EffectiveMessage))
+}
 
-	text := ctx.EffectiveMessage.GetText()
-	return m.db.UpsertMessage(ctx.EffectiveChat.Id, ctx.EffectiveSender.Id(), ctx.EffectiveMessage.MessageId, text, ctx.EffectiveMessage.Date)
+// relationsFromMessage returns the reply, thread and forward origin of a message
+func relationsFromMessage(msg *gotgbot.Message) MessageRelations {
+	var relations MessageRelations
+	// the thread id of other messages is the root of their reply chain, only topics are stored
+	if msg.IsTopicMessage {
+		relations.ThreadId = msg.MessageThreadId
+	}
+	// messages in forum topics reply to the topic creation message unless they are real replies
+	if reply := msg.ReplyToMessage; reply != nil && reply.ForumTopicCreated == nil {
+		relations.ReplyToMsgId = reply.MessageId
+	}
+	if msg.ForwardOrigin != nil {
+		origin := msg.ForwardOrigin.MergeMessageOrigin()
+		switch {
+		case origin.SenderUser != nil:
+			relations.ForwardFromId = origin.SenderUser.Id
+			relations.ForwardFromName = strings.TrimSpace(fmt.Sprintf("%s %s", origin.SenderUser.FirstName, origin.SenderUser.LastName))
+		case origin.SenderChat != nil:
+			relations.ForwardFromId = origin.SenderChat.Id
+			relations.ForwardFromName = origin.SenderChat.Title
+		case origin.Chat != nil:
+			relations.ForwardFromId = origin.Chat.Id
+			relations.ForwardFromName = origin.Chat.Title
+		default:
+			relations.ForwardFromName = origin.SenderUserName
+		}
+	}
+	return relations
+}
+
+// entitiesFromMessage returns the indexed entities of the text or caption of a message
+func entitiesFromMessage(msg *gotgbot.Message) []*models.MessageEntity {
+	parsed := msg.ParseEntities()
+	if msg.Text == "" {
+		parsed = msg.ParseCaptionEntities()
+	}
+	var entities []*models.MessageEntity
+	for _, e := range parsed {
+		switch e.Type {
+		case EntityHashtag, EntityMention, EntityURL:
+			entities = append(entities, &models.MessageEntity{Type: e.Type, Value: e.Text})
+		case EntityTextMention:
+			if e.User != nil {
+				entities = append(entities, &models.MessageEntity{Type: e.Type, Value: strconv.FormatInt(e.User.Id, 10)})
+			}
+		case EntityTextLink:
+			entities = append(entities, &models.MessageEntity{Type: e.Type, Value: e.Url})
+		}
+	}
+	return entities
+}
+
+// mediaFromMessage returns the media of a message or nil, photos are stored in their largest size
+func mediaFromMessage(msg *gotgbot.Message) *models.Medium {
+	switch {
+	case len(msg.Photo) > 0:
+		photo := msg.Photo[len(msg.Photo)-1]
+		return &models.Medium{Type: MediaPhoto, FileID: photo.FileId, FileUniqueID: photo.FileUniqueId, FileSize: photo.FileSize}
+	// animations are sent with a document as well
+	case msg.Animation != nil:
+		a := msg.Animation
+		return &models.Medium{Type: MediaAnimation, FileID: a.FileId, FileUniqueID: a.FileUniqueId, FileName: a.FileName, MimeType: a.MimeType, FileSize: a.FileSize, Duration: a.Duration}
+	case msg.Audio != nil:
+		a := msg.Audio
+		return &models.Medium{Type: MediaAudio, FileID: a.FileId, FileUniqueID: a.FileUniqueId, FileName: a.FileName, MimeType: a.MimeType, FileSize: a.FileSize, Duration: a.Duration}
+	case msg.Document != nil:
+		d := msg.Document
+		return &models.Medium{Type: MediaDocument, FileID: d.FileId, FileUniqueID: d.FileUniqueId, FileName: d.FileName, MimeType: d.MimeType, FileSize: d.FileSize}
+	case msg.Sticker != nil:
+		s := msg.Sticker
+		return &models.Medium{Type: MediaSticker, FileID: s.FileId, FileUniqueID: s.FileUniqueId, FileSize: s.FileSize}
+	case msg.Video != nil:
+		v := msg.Video
+		return &models.Medium{Type: MediaVideo, FileID: v.FileId, FileUniqueID: v.FileUniqueId, FileName: v.FileName, MimeType: v.MimeType, FileSize: v.FileSize, Duration: v.Duration}
+	case msg.VideoNote != nil:
+		v := msg.VideoNote
+		return &models.Medium{Type: MediaVideoNote, FileID: v.FileId, FileUniqueID: v.FileUniqueId, FileSize: v.FileSize, Duration: v.Duration}
+	case msg.Voice != nil:
+		v := msg.Voice
+		return &models.Medium{Type: MediaVoice, FileID: v.FileId, FileUniqueID: v.FileUniqueId, MimeType: v.MimeType, FileSize: v.FileSize, Duration: v.Duration}
+	}
+	return nil
 }
 
 func (m *SearchBot) GetChatAdministrators(chatId int64) ([]gotgbot.ChatMember, error) {
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestResolveTelegramLink(t *testing.T) {
+	d := newTestDatabase(t)
+	if err := d.UpsertChat(-1001234567890, "My Group", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.UpdateChatProfile(-1001234567890, "My Group", "mygroup"); err != nil {
+		t.Fatal(err)
+	}
+	m := &SearchBot{db: d}
+
+	tests := []struct {
+		text   string
+		chatId int64
+		msgId  int64
+		ok     bool
+	}{
+		{"https://t.me/c/1234567890/42", -1001234567890, 42, true},
+		{"https://t.me/c/1234567890/7/42", -1001234567890, 42, true},
+		{"https://t.me/mygroup/42", -1001234567890, 42, true},
+		{"https://t.me/mygroup/7/42", -1001234567890, 42, true},
+		// public chats which were never seen can not be resolved
+		{"https://t.me/othergroup/42", 0, 0, false},
+		{"https://t.me/joinchat/abcdef", 0, 0, false},
+		{"/goto", 0, 0, false},
+	}
+	for _, test := range tests {
+		chatId, msgId, ok, err := m.resolveTelegramLink(test.text)
+		if err != nil {
+			t.Errorf("resolveTelegramLink(%q): %v", test.text, err)
+			continue
+		}
+		if chatId != test.chatId || msgId != test.msgId || ok != test.ok {
+			t.Errorf("resolveTelegramLink(%q) = %d, %d, %v, want %d, %d, %v", test.text, chatId, msgId, ok, test.chatId, test.msgId, test.ok)
+		}
+	}
+}
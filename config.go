@@ -2,14 +2,17 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	BotToken          string `yaml:"bot_token"`
-	CustomBotAPI      string `yaml:"custom_bot_api"`
-	DropPendingUpdate bool   `yaml:"drop_pending_update"`
+	BotToken          string        `yaml:"bot_token"`
+	CustomBotAPI      string        `yaml:"custom_bot_api"`
+	DropPendingUpdate bool          `yaml:"drop_pending_update"`
+	Timezone          string        `yaml:"timezone"`
+	PurgeGracePeriod  time.Duration `yaml:"purge_grace_period"`
 }
 
 func ParseConfig(configFile string) (*Config, error) {
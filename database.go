@@ -5,14 +5,22 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/JasonKhew96/telegram-search-bot-go/models"
 	"github.com/liuzl/gocc"
 	migrate "github.com/rubenv/sql-migrate"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/width"
 	_ "modernc.org/sqlite"
 )
 
@@ -21,6 +29,9 @@ CREATE TABLE "chat" (
     "id" INTEGER NOT NULL,
     "title" TEXT NOT NULL,
     "enabled" BOOLEAN NOT NULL,
+    "sort_order" TEXT NOT NULL DEFAULT 'time',
+    "timezone" TEXT NOT NULL DEFAULT '',
+    "username" TEXT NOT NULL DEFAULT '',
     PRIMARY KEY("id")
 );
 
@@ -39,6 +50,15 @@ CREATE TABLE "message" (
     "text" TEXT NOT NULL,
     "timestamp" DATETIME NOT NULL,
     "deleted_at" DATETIME,
+    "has_media" BOOLEAN NOT NULL DEFAULT 0,
+    "search_text" TEXT,
+    "segmented_text" TEXT,
+    "deleted_by" INTEGER,
+    "formatting" TEXT NOT NULL DEFAULT '',
+    "reply_to_msg_id" INTEGER,
+    "message_thread_id" INTEGER,
+    "forward_from_id" INTEGER,
+    "forward_from_name" TEXT NOT NULL DEFAULT '',
     PRIMARY KEY("id")
 );
 
@@ -51,29 +71,206 @@ CREATE INDEX "idx_message" ON "message" (
     "deleted_at"
 );
 
+CREATE INDEX "idx_message_timestamp" ON "message" ("timestamp", "chat_id");
+
+CREATE INDEX "idx_message_deleted_at" ON "message" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+CREATE INDEX "idx_message_reindex" ON "message" ("id") WHERE "search_text" IS NULL OR "segmented_text" IS NULL;
+
+CREATE INDEX "idx_message_reply" ON "message" ("chat_id", "reply_to_msg_id") WHERE "reply_to_msg_id" IS NOT NULL;
+
+CREATE VIRTUAL TABLE "message_fts" USING fts5(
+    "search_text",
+    content='message',
+    content_rowid='rowid',
+    tokenize='trigram'
+);
+
+CREATE VIRTUAL TABLE "message_word_fts" USING fts5(
+    "segmented_text",
+    content='message',
+    content_rowid='rowid',
+    tokenize='unicode61'
+);
+
 CREATE TABLE "peer" (
     "id" INTEGER NOT NULL,
     "full_name" TEXT NOT NULL,
     "username" TEXT NOT NULL,
+    "timezone" TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY("id")
+);
+
+CREATE TABLE "message_revision" (
+    "id" INTEGER NOT NULL,
+    "message_id" TEXT NOT NULL,
+    "text" TEXT NOT NULL,
+    "search_text" TEXT NOT NULL,
+    "edit_date" DATETIME NOT NULL,
     PRIMARY KEY("id")
 );
+
+CREATE INDEX "idx_message_revision" ON "message_revision" ("message_id", "edit_date");
+
+CREATE TABLE "media" (
+    "message_id" TEXT NOT NULL,
+    "type" TEXT NOT NULL,
+    "file_id" TEXT NOT NULL DEFAULT '',
+    "file_unique_id" TEXT NOT NULL DEFAULT '',
+    "file_name" TEXT NOT NULL DEFAULT '',
+    "mime_type" TEXT NOT NULL DEFAULT '',
+    "file_size" INTEGER NOT NULL DEFAULT 0,
+    "duration" INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY("message_id")
+);
+
+CREATE INDEX "idx_media_type" ON "media" ("type");
+
+CREATE TABLE "message_entity" (
+    "id" INTEGER NOT NULL,
+    "message_id" TEXT NOT NULL,
+    "type" TEXT NOT NULL,
+    "value" TEXT NOT NULL,
+    "domain" TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY("id")
+);
+
+CREATE INDEX "idx_message_entity_message_id" ON "message_entity" ("message_id");
+
+CREATE INDEX "idx_message_entity_value" ON "message_entity" ("type", "value");
+
+CREATE INDEX "idx_message_entity_domain" ON "message_entity" ("domain") WHERE "domain" != '';
+
+CREATE TABLE "forum_topic" (
+    "chat_id" INTEGER NOT NULL,
+    "thread_id" INTEGER NOT NULL,
+    "name" TEXT NOT NULL,
+    PRIMARY KEY("chat_id", "thread_id")
+);
+
+CREATE TABLE "import_checkpoint" (
+    "chat_id" INTEGER NOT NULL,
+    "source" TEXT NOT NULL,
+    "last_msg_id" INTEGER NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    PRIMARY KEY("chat_id", "source")
+);
 */
 
+const (
+	SortOrderTime      = "time"
+	SortOrderRelevance = "relevance"
+)
+
+const (
+	MediaPhoto     = "photo"
+	MediaVideo     = "video"
+	MediaAnimation = "animation"
+	MediaAudio     = "audio"
+	MediaVoice     = "voice"
+	MediaVideoNote = "video_note"
+	MediaSticker   = "sticker"
+	MediaDocument  = "document"
+)
+
+// entity types which are indexed, the values of mentions and hashtags are stored lower cased
+// without their prefix and the value of a text_mention is the user id
+const (
+	EntityHashtag     = "hashtag"
+	EntityMention     = "mention"
+	EntityTextMention = "text_mention"
+	EntityURL         = "url"
+	EntityTextLink    = "text_link"
+)
+
+// MessageRelations are the reply, thread and forward origin of a message, zero values are stored as null
+type MessageRelations struct {
+	ReplyToMsgId    int64
+	ThreadId        int64
+	ForwardFromId   int64
+	ForwardFromName string
+}
+
+type HashtagCount struct {
+	Tag   string `boil:"value"`
+	Count int64  `boil:"count"`
+}
+
 type Database struct {
-	db  *sql.DB
-	ctx context.Context
-	s2t *gocc.OpenCC
-	t2s *gocc.OpenCC
+	db        *sql.DB
+	ctx       context.Context
+	tw2s      *gocc.OpenCC
+	hk2s      *gocc.OpenCC
+	segmenter *Segmenter
+}
+
+// SearchCursor is the position after the last result of a page, chronological results are
+// paginated by keyset while ranked results use an offset as their scores are computed for
+// every match anyway
+type SearchCursor struct {
+	Timestamp int64
+	RowId     int64
+	Offset    int
+}
+
+// ParseSearchCursor parses the next_offset of an inline query, an empty offset is the first page
+func ParseSearchCursor(offset string) (*SearchCursor, error) {
+	if offset == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(offset, "o") {
+		n, err := strconv.Atoi(offset[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &SearchCursor{Offset: n}, nil
+	}
+	timestamp, rowId, found := strings.Cut(offset, "_")
+	if !found {
+		return nil, fmt.Errorf("invalid search cursor %s", offset)
+	}
+	cursor := SearchCursor{}
+	var err error
+	if cursor.Timestamp, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return nil, err
+	}
+	if cursor.RowId, err = strconv.ParseInt(rowId, 10, 64); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+func (c *SearchCursor) String() string {
+	if c.Offset > 0 {
+		return "o" + strconv.Itoa(c.Offset)
+	}
+	return strconv.FormatInt(c.Timestamp, 10) + "_" + strconv.FormatInt(c.RowId, 10)
 }
 
 type MessageAndPeer struct {
-	TotalCount     int `boil:"total_count"`
-	models.Message `boil:",bind"`
-	models.Peer    `boil:",bind"`
-	models.Chat    `boil:",bind"`
+	RowId          int64       `boil:"row_id"`
+	MediaType      null.String `boil:"media_type"`
+	FileID         null.String `boil:"file_id"`
+	FileName       null.String `boil:"file_name"`
+	TopicName      null.String `boil:"topic_name"`
+	ChatUsername   string      `boil:"chat_username"`
+	models.Message `boil:",bind" json:"message"`
+	models.Peer    `boil:",bind" json:"peer"`
+	models.Chat    `boil:",bind" json:"chat"`
 }
 
-func NewDatabase(databaseFile string, importMode bool) (*Database, error) {
+// DisplayText returns the text of the message, or a label of its media if it has no caption
+func (mnp *MessageAndPeer) DisplayText() string {
+	if mnp.Text != "" || !mnp.MediaType.Valid {
+		return mnp.Text
+	}
+	if mnp.FileName.String == "" {
+		return "[" + mnp.MediaType.String + "]"
+	}
+	return "[" + mnp.MediaType.String + "] " + mnp.FileName.String
+}
+
+func NewDatabase(databaseFile, dictionaryFile string, importMode bool) (*Database, error) {
 	// boil.DebugMode = true
 	db, err := sql.Open("sqlite", fmt.Sprintf("%s?cache=shared", databaseFile))
 	if err != nil {
@@ -111,6 +308,285 @@ func NewDatabase(databaseFile string, importMode bool) (*Database, error) {
 					`CREATE INDEX "idx_message" ON "message" ("chat_id", "from_id", "msg_id", "text", "timestamp", "deleted");`,
 				},
 			},
+			{
+				// external content table keyed on the implicit rowid of "message",
+				// run `INSERT INTO "message_fts"("message_fts") VALUES ('rebuild');` after a VACUUM
+				Id: "3_message_fts",
+				Up: []string{
+					`CREATE VIRTUAL TABLE "message_fts" USING fts5("text", content='message', content_rowid='rowid', tokenize='trigram');`,
+					`CREATE TRIGGER "message_fts_ai" AFTER INSERT ON "message" BEGIN
+						INSERT INTO "message_fts"(rowid, "text") VALUES (new.rowid, new.text);
+					END;`,
+					`CREATE TRIGGER "message_fts_ad" AFTER DELETE ON "message" BEGIN
+						INSERT INTO "message_fts"("message_fts", rowid, "text") VALUES ('delete', old.rowid, old.text);
+					END;`,
+					`CREATE TRIGGER "message_fts_au" AFTER UPDATE OF "text" ON "message" BEGIN
+						INSERT INTO "message_fts"("message_fts", rowid, "text") VALUES ('delete', old.rowid, old.text);
+						INSERT INTO "message_fts"(rowid, "text") VALUES (new.rowid, new.text);
+					END;`,
+					`INSERT INTO "message_fts"("message_fts") VALUES ('rebuild');`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS "message_fts_au";`,
+					`DROP TRIGGER IF EXISTS "message_fts_ad";`,
+					`DROP TRIGGER IF EXISTS "message_fts_ai";`,
+					`DROP TABLE IF EXISTS "message_fts";`,
+				},
+			},
+			{
+				Id: "4_chat_sort_order",
+				Up: []string{
+					`ALTER TABLE "chat" ADD COLUMN "sort_order" TEXT NOT NULL DEFAULT 'time';`,
+				},
+				Down: []string{
+					`ALTER TABLE "chat" DROP COLUMN "sort_order";`,
+				},
+			},
+			{
+				Id: "5_message_has_media",
+				Up: []string{
+					`ALTER TABLE "message" ADD COLUMN "has_media" BOOLEAN NOT NULL DEFAULT 0;`,
+				},
+				Down: []string{
+					`ALTER TABLE "message" DROP COLUMN "has_media";`,
+				},
+			},
+			{
+				// rows with a NULL "search_text" are normalized by reindexMessages after the migrations,
+				// they are not in the fts index yet so deleting them from it must be skipped
+				Id: "6_message_search_text",
+				Up: []string{
+					`DROP TRIGGER IF EXISTS "message_fts_au";`,
+					`DROP TRIGGER IF EXISTS "message_fts_ad";`,
+					`DROP TRIGGER IF EXISTS "message_fts_ai";`,
+					`DROP TABLE IF EXISTS "message_fts";`,
+					`ALTER TABLE "message" ADD COLUMN "search_text" TEXT;`,
+					`CREATE INDEX "idx_message_search_text_null" ON "message" ("id") WHERE "search_text" IS NULL;`,
+					`CREATE VIRTUAL TABLE "message_fts" USING fts5("search_text", content='message', content_rowid='rowid', tokenize='trigram');`,
+					`CREATE TRIGGER "message_fts_ai" AFTER INSERT ON "message" BEGIN
+						INSERT INTO "message_fts"(rowid, "search_text") VALUES (new.rowid, new.search_text);
+					END;`,
+					`CREATE TRIGGER "message_fts_ad" AFTER DELETE ON "message" BEGIN
+						INSERT INTO "message_fts"("message_fts", rowid, "search_text") SELECT 'delete', old.rowid, old.search_text WHERE old.search_text IS NOT NULL;
+					END;`,
+					`CREATE TRIGGER "message_fts_au" AFTER UPDATE OF "search_text" ON "message" BEGIN
+						INSERT INTO "message_fts"("message_fts", rowid, "search_text") SELECT 'delete', old.rowid, old.search_text WHERE old.search_text IS NOT NULL;
+						INSERT INTO "message_fts"(rowid, "search_text") VALUES (new.rowid, new.search_text);
+					END;`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS "message_fts_au";`,
+					`DROP TRIGGER IF EXISTS "message_fts_ad";`,
+					`DROP TRIGGER IF EXISTS "message_fts_ai";`,
+					`DROP TABLE IF EXISTS "message_fts";`,
+					`DROP INDEX IF EXISTS "idx_message_search_text_null";`,
+					`ALTER TABLE "message" DROP COLUMN "search_text";`,
+					`CREATE VIRTUAL TABLE "message_fts" USING fts5("text", content='message', content_rowid='rowid', tokenize='trigram');`,
+					`CREATE TRIGGER "message_fts_ai" AFTER INSERT ON "message" BEGIN
+						INSERT INTO "message_fts"(rowid, "text") VALUES (new.rowid, new.text);
+					END;`,
+					`CREATE TRIGGER "message_fts_ad" AFTER DELETE ON "message" BEGIN
+						INSERT INTO "message_fts"("message_fts", rowid, "text") VALUES ('delete', old.rowid, old.text);
+					END;`,
+					`CREATE TRIGGER "message_fts_au" AFTER UPDATE OF "text" ON "message" BEGIN
+						INSERT INTO "message_fts"("message_fts", rowid, "text") VALUES ('delete', old.rowid, old.text);
+						INSERT INTO "message_fts"(rowid, "text") VALUES (new.rowid, new.text);
+					END;`,
+					`INSERT INTO "message_fts"("message_fts") VALUES ('rebuild');`,
+				},
+			},
+			{
+				// rows with a NULL "segmented_text" are segmented by reindexMessages after the migrations
+				Id: "7_message_segmented_text",
+				Up: []string{
+					`DROP INDEX IF EXISTS "idx_message_search_text_null";`,
+					`ALTER TABLE "message" ADD COLUMN "segmented_text" TEXT;`,
+					`CREATE INDEX "idx_message_reindex" ON "message" ("id") WHERE "search_text" IS NULL OR "segmented_text" IS NULL;`,
+					`CREATE VIRTUAL TABLE "message_word_fts" USING fts5("segmented_text", content='message', content_rowid='rowid', tokenize='unicode61');`,
+					`CREATE TRIGGER "message_word_fts_ai" AFTER INSERT ON "message" BEGIN
+						INSERT INTO "message_word_fts"(rowid, "segmented_text") VALUES (new.rowid, new.segmented_text);
+					END;`,
+					`CREATE TRIGGER "message_word_fts_ad" AFTER DELETE ON "message" BEGIN
+						INSERT INTO "message_word_fts"("message_word_fts", rowid, "segmented_text") SELECT 'delete', old.rowid, old.segmented_text WHERE old.segmented_text IS NOT NULL;
+					END;`,
+					`CREATE TRIGGER "message_word_fts_au" AFTER UPDATE OF "segmented_text" ON "message" BEGIN
+						INSERT INTO "message_word_fts"("message_word_fts", rowid, "segmented_text") SELECT 'delete', old.rowid, old.segmented_text WHERE old.segmented_text IS NOT NULL;
+						INSERT INTO "message_word_fts"(rowid, "segmented_text") VALUES (new.rowid, new.segmented_text);
+					END;`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS "message_word_fts_au";`,
+					`DROP TRIGGER IF EXISTS "message_word_fts_ad";`,
+					`DROP TRIGGER IF EXISTS "message_word_fts_ai";`,
+					`DROP TABLE IF EXISTS "message_word_fts";`,
+					`DROP INDEX IF EXISTS "idx_message_reindex";`,
+					`ALTER TABLE "message" DROP COLUMN "segmented_text";`,
+					`CREATE INDEX "idx_message_search_text_null" ON "message" ("id") WHERE "search_text" IS NULL;`,
+				},
+			},
+			{
+				Id: "8_message_timestamp_index",
+				Up: []string{
+					`CREATE INDEX "idx_message_timestamp" ON "message" ("timestamp", "chat_id");`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS "idx_message_timestamp";`,
+				},
+			},
+			{
+				Id: "9_timezone",
+				Up: []string{
+					`ALTER TABLE "chat" ADD COLUMN "timezone" TEXT NOT NULL DEFAULT '';`,
+					`ALTER TABLE "peer" ADD COLUMN "timezone" TEXT NOT NULL DEFAULT '';`,
+				},
+				Down: []string{
+					`ALTER TABLE "chat" DROP COLUMN "timezone";`,
+					`ALTER TABLE "peer" DROP COLUMN "timezone";`,
+				},
+			},
+			{
+				Id: "10_message_deleted_by",
+				Up: []string{
+					`ALTER TABLE "message" ADD COLUMN "deleted_by" INTEGER;`,
+					`CREATE INDEX "idx_message_deleted_at" ON "message" ("deleted_at") WHERE "deleted_at" IS NOT NULL;`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS "idx_message_deleted_at";`,
+					`ALTER TABLE "message" DROP COLUMN "deleted_by";`,
+				},
+			},
+			{
+				Id: "11_message_revision",
+				Up: []string{
+					`CREATE TABLE "message_revision" (
+						"id" INTEGER NOT NULL,
+						"message_id" TEXT NOT NULL,
+						"text" TEXT NOT NULL,
+						"search_text" TEXT NOT NULL,
+						"edit_date" DATETIME NOT NULL,
+						PRIMARY KEY("id")
+					);`,
+					`CREATE INDEX "idx_message_revision" ON "message_revision" ("message_id", "edit_date");`,
+					`CREATE TRIGGER "message_revision_ad" AFTER DELETE ON "message" BEGIN
+						DELETE FROM "message_revision" WHERE "message_id" = old."id";
+					END;`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS "message_revision_ad";`,
+					`DROP TABLE IF EXISTS "message_revision";`,
+				},
+			},
+			{
+				Id: "12_media",
+				Up: []string{
+					`CREATE TABLE "media" (
+						"message_id" TEXT NOT NULL,
+						"type" TEXT NOT NULL,
+						"file_id" TEXT NOT NULL DEFAULT '',
+						"file_unique_id" TEXT NOT NULL DEFAULT '',
+						"file_name" TEXT NOT NULL DEFAULT '',
+						"mime_type" TEXT NOT NULL DEFAULT '',
+						"file_size" INTEGER NOT NULL DEFAULT 0,
+						"duration" INTEGER NOT NULL DEFAULT 0,
+						PRIMARY KEY("message_id")
+					);`,
+					`CREATE INDEX "idx_media_type" ON "media" ("type");`,
+					`CREATE TRIGGER "media_ad" AFTER DELETE ON "message" BEGIN
+						DELETE FROM "media" WHERE "message_id" = old."id";
+					END;`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS "media_ad";`,
+					`DROP TABLE IF EXISTS "media";`,
+				},
+			},
+			{
+				Id: "13_message_entity",
+				Up: []string{
+					`CREATE TABLE "message_entity" (
+						"id" INTEGER NOT NULL,
+						"message_id" TEXT NOT NULL,
+						"type" TEXT NOT NULL,
+						"value" TEXT NOT NULL,
+						"domain" TEXT NOT NULL DEFAULT '',
+						PRIMARY KEY("id")
+					);`,
+					`CREATE INDEX "idx_message_entity_message_id" ON "message_entity" ("message_id");`,
+					`CREATE INDEX "idx_message_entity_value" ON "message_entity" ("type", "value");`,
+					`CREATE INDEX "idx_message_entity_domain" ON "message_entity" ("domain") WHERE "domain" != '';`,
+					`CREATE TRIGGER "message_entity_ad" AFTER DELETE ON "message" BEGIN
+						DELETE FROM "message_entity" WHERE "message_id" = old."id";
+					END;`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS "message_entity_ad";`,
+					`DROP TABLE IF EXISTS "message_entity";`,
+				},
+			},
+			{
+				Id: "14_message_formatting",
+				Up: []string{
+					`ALTER TABLE "message" ADD COLUMN "formatting" TEXT NOT NULL DEFAULT '';`,
+				},
+				Down: []string{
+					`ALTER TABLE "message" DROP COLUMN "formatting";`,
+				},
+			},
+			{
+				Id: "15_message_reply",
+				Up: []string{
+					`ALTER TABLE "message" ADD COLUMN "reply_to_msg_id" INTEGER;`,
+					`ALTER TABLE "message" ADD COLUMN "message_thread_id" INTEGER;`,
+					`ALTER TABLE "message" ADD COLUMN "forward_from_id" INTEGER;`,
+					`ALTER TABLE "message" ADD COLUMN "forward_from_name" TEXT NOT NULL DEFAULT '';`,
+					`CREATE INDEX "idx_message_reply" ON "message" ("chat_id", "reply_to_msg_id") WHERE "reply_to_msg_id" IS NOT NULL;`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS "idx_message_reply";`,
+					`ALTER TABLE "message" DROP COLUMN "forward_from_name";`,
+					`ALTER TABLE "message" DROP COLUMN "forward_from_id";`,
+					`ALTER TABLE "message" DROP COLUMN "message_thread_id";`,
+					`ALTER TABLE "message" DROP COLUMN "reply_to_msg_id";`,
+				},
+			},
+			{
+				Id: "16_forum_topic",
+				Up: []string{
+					`CREATE TABLE "forum_topic" (
+						"chat_id" INTEGER NOT NULL,
+						"thread_id" INTEGER NOT NULL,
+						"name" TEXT NOT NULL,
+						PRIMARY KEY("chat_id", "thread_id")
+					);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS "forum_topic";`,
+				},
+			},
+			{
+				Id: "17_chat_username",
+				Up: []string{
+					`ALTER TABLE "chat" ADD COLUMN "username" TEXT NOT NULL DEFAULT '';`,
+				},
+				Down: []string{
+					`ALTER TABLE "chat" DROP COLUMN "username";`,
+				},
+			},
+			{
+				Id: "18_import_checkpoint",
+				Up: []string{
+					`CREATE TABLE "import_checkpoint" (
+						"chat_id" INTEGER NOT NULL,
+						"source" TEXT NOT NULL,
+						"last_msg_id" INTEGER NOT NULL,
+						"updated_at" DATETIME NOT NULL,
+						PRIMARY KEY("chat_id", "source")
+					);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS "import_checkpoint";`,
+				},
+			},
 		},
 	}
 	migrationCount, err := migrate.Exec(db, "sqlite3", migrations, migrate.Up)
@@ -120,30 +596,75 @@ func NewDatabase(databaseFile string, importMode bool) (*Database, error) {
 	log.Printf("Applied %d migrations", migrationCount)
 	// migrations end
 
-	s2t, err := gocc.New("s2t")
+	tw2s, err := gocc.New("tw2s")
 	if err != nil {
 		return nil, err
 	}
-	t2s, err := gocc.New("t2s")
+	hk2s, err := gocc.New("hk2s")
 	if err != nil {
 		return nil, err
 	}
 
 	if importMode {
 		// https://avi.im/blag/2021/fast-sqlite-inserts/
-		db.Exec("PRAGMA journal_mode = OFF;")
-		db.Exec("PRAGMA synchronous = 0;")
+		// imports are committed in batches with their checkpoint, the journal is kept so an interrupted
+		// import leaves the last committed batch intact and can resume from its checkpoint
+		db.SetMaxOpenConns(1)
+		db.Exec("PRAGMA journal_mode = WAL;")
+		db.Exec("PRAGMA synchronous = NORMAL;")
 		db.Exec("PRAGMA cache_size = 1000000;")
 		db.Exec("PRAGMA locking_mode = EXCLUSIVE;")
 		db.Exec("PRAGMA temp_store = MEMORY;")
 	}
 
-	return &Database{
-		db:  db,
-		ctx: context.Background(),
-		s2t: s2t,
-		t2s: t2s,
-	}, nil
+	d := &Database{
+		db:   db,
+		ctx:  context.Background(),
+		tw2s: tw2s,
+		hk2s: hk2s,
+	}
+	d.segmenter, err = NewSegmenter(dictionaryFile, d.normalize)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.reindexMessages(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// reindexMessages fills "search_text" and "segmented_text" of messages indexed before the normalization pipeline existed or changed
+func (d *Database) reindexMessages() error {
+	count := 0
+	for {
+		// same condition as "idx_message_reindex" so the partial index is used
+		messages, err := models.Messages(qm.Where(`"search_text" IS NULL OR "segmented_text" IS NULL`), qm.WithDeleted(), qm.Limit(10000)).All(d.ctx, d.db)
+		if err != nil {
+			return err
+		}
+		if len(messages) <= 0 {
+			break
+		}
+		tx, err := d.db.BeginTx(d.ctx, nil)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			searchText := d.normalize(message.Text)
+			message.SearchText = null.StringFrom(searchText)
+			message.SegmentedText = null.StringFrom(d.segment(searchText))
+			if _, err := message.Update(d.ctx, tx, boil.Whitelist(models.MessageColumns.SearchText, models.MessageColumns.SegmentedText)); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		count += len(messages)
+		log.Printf("reindexed %d messages", count)
+	}
+	return nil
 }
 
 func (d *Database) Close() error {
@@ -154,6 +675,35 @@ func (d *Database) GetChat(chatId int64) (*models.Chat, error) {
 	return models.Chats(models.ChatWhere.ID.EQ(chatId)).One(d.ctx, d.db)
 }
 
+// GetChatByUsername returns the chat of a public chat username
+func (d *Database) GetChatByUsername(username string) (*models.Chat, error) {
+	return models.Chats(qm.Where("username = ? COLLATE NOCASE", username)).One(d.ctx, d.db)
+}
+
+func (d *Database) GetChats(chatIds []int64) ([]*models.Chat, error) {
+	return models.Chats(models.ChatWhere.ID.IN(chatIds)).All(d.ctx, d.db)
+}
+
+func (d *Database) UpdateChatSortOrder(chatId int64, sortOrder string) error {
+	chat, err := d.GetChat(chatId)
+	if err != nil {
+		return err
+	}
+	chat.SortOrder = sortOrder
+	_, err = chat.Update(d.ctx, d.db, boil.Whitelist(models.ChatColumns.SortOrder))
+	return err
+}
+
+func (d *Database) UpdateChatTimezone(chatId int64, timezone string) error {
+	chat, err := d.GetChat(chatId)
+	if err != nil {
+		return err
+	}
+	chat.Timezone = timezone
+	_, err = chat.Update(d.ctx, d.db, boil.Whitelist(models.ChatColumns.Timezone))
+	return err
+}
+
 func (d *Database) UpdateChat(chatId int64, title string, enabled bool) error {
 	chat, err := d.GetChat(chatId)
 	if err != nil {
@@ -165,13 +715,28 @@ func (d *Database) UpdateChat(chatId int64, title string, enabled bool) error {
 	return err
 }
 
+// UpdateChatProfile keeps the title and username of a chat up to date
+func (d *Database) UpdateChatProfile(chatId int64, title, username string) error {
+	chat, err := d.GetChat(chatId)
+	if err != nil {
+		return err
+	}
+	if chat.Title == title && chat.Username == username {
+		return nil
+	}
+	chat.Title = title
+	chat.Username = username
+	_, err = chat.Update(d.ctx, d.db, boil.Whitelist(models.ChatColumns.Title, models.ChatColumns.Username))
+	return err
+}
+
 func (d *Database) UpsertChat(chatId int64, title string, enabled bool) error {
 	chat := models.Chat{
 		ID:      chatId,
 		Title:   title,
 		Enabled: enabled,
 	}
-	return chat.Upsert(d.ctx, d.db, true, []string{"id"}, boil.Infer(), boil.Infer())
+	return chat.Upsert(d.ctx, d.db, true, []string{"id"}, boil.Whitelist(models.ChatColumns.Title, models.ChatColumns.Enabled), boil.Infer())
 }
 
 func (d *Database) GetPeer(peerId int64) (*models.Peer, error) {
@@ -179,12 +744,26 @@ func (d *Database) GetPeer(peerId int64) (*models.Peer, error) {
 }
 
 func (d *Database) UpsertPeer(peerId int64, fullName, username string) error {
+	return d.upsertPeer(d.db, peerId, fullName, username, models.PeerColumns.FullName, models.PeerColumns.Username)
+}
+
+func (d *Database) upsertPeer(exec boil.ContextExecutor, peerId int64, fullName, username string, updateColumns ...string) error {
 	peer := models.Peer{
 		ID:       peerId,
 		FullName: fullName,
 		Username: username,
 	}
-	return peer.Upsert(d.ctx, d.db, true, []string{"id"}, boil.Infer(), boil.Infer())
+	return peer.Upsert(d.ctx, exec, true, []string{"id"}, boil.Whitelist(updateColumns...), boil.Infer())
+}
+
+func (d *Database) UpdatePeerTimezone(peerId int64, timezone string) error {
+	peer, err := d.GetPeer(peerId)
+	if err != nil {
+		return err
+	}
+	peer.Timezone = timezone
+	_, err = peer.Update(d.ctx, d.db, boil.Whitelist(models.PeerColumns.Timezone))
+	return err
 }
 
 func (d *Database) GetMessageCount() (int64, error) {
@@ -195,67 +774,664 @@ func (d *Database) GetMessage(chatId int64, msgId int64) (*models.Message, error
 	return models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.MSGID.EQ(msgId), models.MessageWhere.DeletedAt.IsNull()).One(d.ctx, d.db)
 }
 
-func (d *Database) SearchMessages(chatId []int64, username string, peerId int64, texts []string, offset int) ([]*MessageAndPeer, error) {
-	queryMods := []qm.QueryMod{qm.Select("message.msg_id", "message.chat_id", "message.text", "message.timestamp", "peer.full_name", "chat.title", "COUNT() OVER() as total_count"), qm.From("message"), qm.InnerJoin("peer on peer.id = message.from_id"), qm.InnerJoin("chat on chat.id = message.chat_id"), models.MessageWhere.DeletedAt.IsNull(), qm.Offset(offset), qm.Limit(49), qm.OrderBy("message.timestamp DESC")}
-	for _, c := range chatId {
-		queryMods = append(queryMods, models.MessageWhere.ChatID.EQ(c))
+// dbTime converts t to the local time zone in whole seconds, which is how timestamps are stored and
+// compared as text by sqlite
+func dbTime(t time.Time) time.Time {
+	return time.Unix(t.Unix(), 0)
+}
+
+// GetFirstMessageSince returns the earliest message of a chat sent at or after t
+func (d *Database) GetFirstMessageSince(chatId int64, t time.Time) (*models.Message, error) {
+	return models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.Timestamp.GTE(dbTime(t)), models.MessageWhere.DeletedAt.IsNull(), qm.OrderBy("timestamp, msg_id")).One(d.ctx, d.db)
+}
+
+// searchFilter is a search query compiled into conditions shared by SearchMessages and CountMessages
+type searchFilter struct {
+	queryMods []qm.QueryMod
+	nodes     []QueryNode
+	matches   []string
+}
+
+func (d *Database) compileSearchQuery(chatId []int64, query *SearchQuery) *searchFilter {
+	queryMods := []qm.QueryMod{qm.From("message"), qm.InnerJoin("peer on peer.id = message.from_id"), qm.InnerJoin("chat on chat.id = message.chat_id"), models.MessageWhere.DeletedAt.IsNull()}
+	queryMods = append(queryMods, models.MessageWhere.ChatID.IN(chatId))
+
+	// positive text conditions at the top level go into a single fts query per index so they can be ranked,
+	// everything else is compiled into plain sql conditions
+	var nodes []QueryNode
+	if and, ok := query.Root.(AndNode); ok {
+		nodes = and.Nodes
+	} else if query.Root != nil {
+		nodes = []QueryNode{query.Root}
 	}
-	if username != "" {
-		queryMods = append(queryMods, models.PeerWhere.Username.EQ(username))
+	var matches []string
+	for _, node := range nodes {
+		// past revisions are not in the fts index, so terms have to be compiled as conditions
+		if query.Revisions {
+			rawQuery, rawArgs := d.compileQueryNode(node, true)
+			queryMods = append(queryMods, qm.And(rawQuery, rawArgs...))
+			continue
+		}
+		if match, ok := d.ftsExpression(node); ok {
+			matches = append(matches, match)
+			continue
+		}
+		rawQuery, rawArgs := d.compileQueryNode(node, false)
+		queryMods = append(queryMods, qm.And(rawQuery, rawArgs...))
 	}
-	if peerId != 0 {
-		queryMods = append(queryMods, models.MessageWhere.FromID.EQ(peerId))
+	// the scores are computed in subqueries so they can be joined and ordered by
+	if len(matches) > 0 {
+		queryMods = append(queryMods, qm.InnerJoin("(SELECT rowid, bm25(message_fts) AS rank FROM message_fts WHERE message_fts MATCH ?) AS fts ON fts.rowid = message.rowid", strings.Join(matches, " AND ")))
 	}
-	for _, q := range texts {
-		t, err := d.s2t.Convert(q)
-		if err != nil {
-			log.Println(err)
+	return &searchFilter{queryMods: queryMods, nodes: nodes, matches: matches}
+}
+
+// messageAndPeerColumns are the columns of a MessageAndPeer, joined columns are aliased so they bind
+var messageAndPeerColumns = []string{"message.rowid as row_id", "message.id", "message.msg_id", "message.chat_id", "message.from_id", "message.text", "message.formatting", "message.timestamp", "message.reply_to_msg_id", "message.message_thread_id", "message.forward_from_name", "peer.full_name", "chat.title", "chat.username as chat_username", "media.type as media_type", "media.file_id as file_id", "media.file_name as file_name", "forum_topic.name as topic_name"}
+
+// SearchMessages returns a page of results after cursor and the cursor of the next page, which is nil on the last page
+func (d *Database) SearchMessages(chatId []int64, query *SearchQuery, cursor *SearchCursor) ([]*MessageAndPeer, *SearchCursor, error) {
+	filter := d.compileSearchQuery(chatId, query)
+	queryMods := append(filter.queryMods, qm.Select(messageAndPeerColumns...), qm.LeftOuterJoin("media on media.message_id = message.id"), qm.LeftOuterJoin("forum_topic on forum_topic.chat_id = message.chat_id and forum_topic.thread_id = message.message_thread_id"), qm.Limit(49))
+
+	// in relevance order whole word hits are ranked above substring hits
+	var orderBy []string
+	if query.SortOrder == SortOrderRelevance {
+		if words := d.rankExpression(filter.nodes); words != "" {
+			queryMods = append(queryMods, qm.LeftOuterJoin("(SELECT rowid, bm25(message_word_fts) AS rank FROM message_word_fts WHERE message_word_fts MATCH ?) AS word_fts ON word_fts.rowid = message.rowid", words))
+			orderBy = append(orderBy, "word_fts.rowid IS NULL", "word_fts.rank")
 		}
-		s, err := d.t2s.Convert(q)
-		if err != nil {
-			log.Println(err)
+		if len(filter.matches) > 0 {
+			orderBy = append(orderBy, "fts.rank")
+		}
+	}
+	ranked := len(orderBy) > 0
+	orderBy = append(orderBy, "message.timestamp DESC", "message.rowid DESC")
+	queryMods = append(queryMods, qm.OrderBy(strings.Join(orderBy, ", ")))
+
+	offset := 0
+	if cursor != nil {
+		if ranked {
+			offset = cursor.Offset
+			queryMods = append(queryMods, qm.Offset(offset))
+		} else if cursor.Offset <= 0 {
+			timestamp := time.Unix(cursor.Timestamp, 0)
+			queryMods = append(queryMods, qm.And("(message.timestamp < ? OR (message.timestamp = ? AND message.rowid < ?))", timestamp, timestamp, cursor.RowId))
+		}
+	}
+
+	var messageAndPeer []*MessageAndPeer
+	if err := models.NewQuery(queryMods...).Bind(d.ctx, d.db, &messageAndPeer); err != nil {
+		return nil, nil, err
+	}
+	if len(messageAndPeer) < 49 {
+		return messageAndPeer, nil, nil
+	}
+	if ranked {
+		return messageAndPeer, &SearchCursor{Offset: offset + len(messageAndPeer)}, nil
+	}
+	last := messageAndPeer[len(messageAndPeer)-1]
+	return messageAndPeer, &SearchCursor{Timestamp: last.Timestamp.Unix(), RowId: last.RowId}, nil
+}
+
+// CountMessages counts the results of a search query up to limit, capped is set when there are more
+func (d *Database) CountMessages(chatId []int64, query *SearchQuery, limit int) (count int, capped bool, err error) {
+	filter := d.compileSearchQuery(chatId, query)
+	queryMods := append(filter.queryMods, qm.Select("1"), qm.Limit(limit+1))
+	rawQuery, args := queries.BuildQuery(models.NewQuery(queryMods...))
+	rawQuery = "SELECT COUNT(*) FROM (" + strings.TrimSuffix(rawQuery, ";") + ")"
+	if err := d.db.QueryRowContext(d.ctx, rawQuery, args...).Scan(&count); err != nil {
+		return 0, false, err
+	}
+	if count > limit {
+		return limit, true, nil
+	}
+	return count, false, nil
+}
+
+// ftsExpression translates a node into a FTS5 query, only terms and their AND/OR combinations are supported
+func (d *Database) ftsExpression(node QueryNode) (string, bool) {
+	switch n := node.(type) {
+	case TermNode:
+		text := d.normalize(n.Text)
+		// the trigram tokenizer can not match anything shorter than 3 characters
+		if utf8.RuneCountInString(text) < 3 {
+			return "", false
+		}
+		return ftsPhrase(text), true
+	case AndNode, OrNode:
+		var children []QueryNode
+		operator := " AND "
+		if or, ok := n.(OrNode); ok {
+			children = or.Nodes
+			operator = " OR "
+		} else {
+			children = n.(AndNode).Nodes
 		}
-		dups := removeDuplicate([]string{q, t, s})
+		var exprs []string
+		for _, child := range children {
+			expr, ok := d.ftsExpression(child)
+			if !ok {
+				return "", false
+			}
+			exprs = append(exprs, expr)
+		}
+		return "(" + strings.Join(exprs, operator) + ")", true
+	}
+	return "", false
+}
+
+// wordExpression returns a word index query narrowing the rows of a chinese term which is too short
+// for the trigram index, every word of the term has to start a word of the message. The LIKE check on
+// the narrowed rows keeps the words adjacent, a term inside a longer word is only found at its start
+func (d *Database) wordExpression(text string) (string, bool) {
+	text = d.normalize(text)
+	if strings.IndexFunc(text, func(r rune) bool { return unicode.Is(unicode.Han, r) }) < 0 {
+		return "", false
+	}
+	var words []string
+	for _, word := range d.segmenter.Segment(text) {
+		words = append(words, ftsPhrase(word)+"*")
+	}
+	if len(words) <= 0 {
+		return "", false
+	}
+	return strings.Join(words, " AND "), true
+}
+
+// rankExpression returns a word index query matching any positive term of nodes
+func (d *Database) rankExpression(nodes []QueryNode) string {
+	var words []string
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case TermNode:
+			if w := d.segment(d.normalize(n.Text)); w != "" {
+				words = append(words, ftsPhrase(w))
+			}
+		case AndNode:
+			if w := d.rankExpression(n.Nodes); w != "" {
+				words = append(words, w)
+			}
+		case OrNode:
+			if w := d.rankExpression(n.Nodes); w != "" {
+				words = append(words, w)
+			}
+		}
+	}
+	return strings.Join(words, " OR ")
+}
+
+// compileQueryNode translates a node into a sql condition, terms also match past revisions if revisions is set
+func (d *Database) compileQueryNode(node QueryNode, revisions bool) (string, []interface{}) {
+	switch n := node.(type) {
+	case TermNode:
 		var rawQuery string
 		var rawArgs []interface{}
-		if len(dups) == 1 {
-			rawQuery += "message.text LIKE ?"
-			rawArgs = append(rawArgs, "%"+dups[0]+"%")
-		} else if len(dups) > 1 {
-			for i, d := range dups {
-				rawQuery += "message.text LIKE ?"
-				rawArgs = append(rawArgs, "%"+d+"%")
-				if i != len(dups)-1 {
-					rawQuery += " OR "
-				}
+		if match, ok := d.ftsExpression(n); ok {
+			rawQuery, rawArgs = "message.rowid IN (SELECT rowid FROM message_fts WHERE message_fts MATCH ?)", []interface{}{match}
+		} else if words, ok := d.wordExpression(n.Text); ok {
+			rawQuery, rawArgs = `(message.rowid IN (SELECT rowid FROM message_word_fts WHERE message_word_fts MATCH ?) AND message.search_text LIKE ? ESCAPE '\')`, []interface{}{words, "%" + escapeLike(d.normalize(n.Text)) + "%"}
+		} else {
+			rawQuery, rawArgs = `message.search_text LIKE ? ESCAPE '\'`, []interface{}{"%" + escapeLike(d.normalize(n.Text)) + "%"}
+		}
+		if !revisions {
+			return rawQuery, rawArgs
+		}
+		return `(` + rawQuery + ` OR message.id IN (SELECT message_id FROM message_revision WHERE search_text LIKE ? ESCAPE '\'))`, append(rawArgs, "%"+escapeLike(d.normalize(n.Text))+"%")
+	case NotNode:
+		rawQuery, rawArgs := d.compileQueryNode(n.Node, revisions)
+		return "NOT " + rawQuery, rawArgs
+	case AndNode, OrNode:
+		var children []QueryNode
+		operator := " AND "
+		if or, ok := n.(OrNode); ok {
+			children = or.Nodes
+			operator = " OR "
+		} else {
+			children = n.(AndNode).Nodes
+		}
+		var rawQuery []string
+		var rawArgs []interface{}
+		for _, child := range children {
+			q, args := d.compileQueryNode(child, revisions)
+			rawQuery = append(rawQuery, q)
+			rawArgs = append(rawArgs, args...)
+		}
+		return "(" + strings.Join(rawQuery, operator) + ")", rawArgs
+	case FromNode:
+		if n.PeerId != 0 {
+			return "message.from_id = ?", []interface{}{n.PeerId}
+		}
+		return "peer.username = ? COLLATE NOCASE", []interface{}{n.Username}
+	case InNode:
+		if n.ChatId != 0 {
+			return "message.chat_id = ?", []interface{}{n.ChatId}
+		}
+		if n.Title == "" {
+			return "chat.username = ? COLLATE NOCASE", []interface{}{n.Username}
+		}
+		return `(chat.title LIKE ? ESCAPE '\' OR chat.username = ? COLLATE NOCASE)`, []interface{}{escapeLike(n.Title) + "%", n.Username}
+	case DateNode:
+		if n.Before {
+			return "message.timestamp < ?", []interface{}{dbTime(n.Time)}
+		}
+		return "message.timestamp >= ?", []interface{}{dbTime(n.Time)}
+	case HasNode:
+		switch n.Kind {
+		case HasMedia:
+			return "message.has_media = 1", nil
+		case HasLink:
+			return `(message.text LIKE '%http://%' OR message.text LIKE '%https://%' OR message.text LIKE '%t.me/%')`, nil
+		case HasFile:
+			return "message.id IN (SELECT message_id FROM media WHERE type = ?)", []interface{}{MediaDocument}
+		}
+		return "message.id IN (SELECT message_id FROM media WHERE type = ?)", []interface{}{n.Kind}
+	case HashtagNode:
+		return "message.id IN (SELECT message_id FROM message_entity WHERE type = ? AND value = ?)", []interface{}{EntityHashtag, strings.ToLower(n.Tag)}
+	case MentionNode:
+		// mentions by username and by user id (for users without a username) are matched both ways through the peer table
+		if n.PeerId != 0 {
+			return "message.id IN (SELECT message_id FROM message_entity WHERE (type = ? AND value = ?) OR (type = ? AND value IN (SELECT lower(username) FROM peer WHERE id = ? AND username != '')))", []interface{}{EntityTextMention, strconv.FormatInt(n.PeerId, 10), EntityMention, n.PeerId}
+		}
+		return "message.id IN (SELECT message_id FROM message_entity WHERE (type = ? AND value = ?) OR (type = ? AND value IN (SELECT CAST(id AS TEXT) FROM peer WHERE username = ? COLLATE NOCASE)))", []interface{}{EntityMention, strings.ToLower(n.Username), EntityTextMention, n.Username}
+	case DomainNode:
+		return `message.id IN (SELECT message_id FROM message_entity WHERE domain = ? OR domain LIKE ? ESCAPE '\')`, []interface{}{n.Domain, "%." + escapeLike(n.Domain)}
+	case ReplyToNode:
+		if n.MsgId != 0 {
+			if n.ChatUsername != "" {
+				return "(chat.username = ? COLLATE NOCASE AND message.reply_to_msg_id IS ?)", []interface{}{n.ChatUsername, n.MsgId}
 			}
+			if n.ChatId != 0 {
+				return "(message.chat_id = ? AND message.reply_to_msg_id IS ?)", []interface{}{n.ChatId, n.MsgId}
+			}
+			// IS instead of = so that excluding replies keeps messages which are not replies
+			return "message.reply_to_msg_id IS ?", []interface{}{n.MsgId}
 		}
-		queryMods = append(queryMods, qm.And(rawQuery, rawArgs...))
+		return "EXISTS (SELECT 1 FROM message AS parent WHERE parent.chat_id = message.chat_id AND parent.msg_id = message.reply_to_msg_id AND parent.from_id IN (SELECT id FROM peer WHERE username = ? COLLATE NOCASE))", []interface{}{n.Username}
+	case TopicNode:
+		if n.ThreadId != 0 {
+			return "message.message_thread_id IS ?", []interface{}{n.ThreadId}
+		}
+		return `(message.message_thread_id IS NOT NULL AND message.message_thread_id IN (SELECT thread_id FROM forum_topic WHERE forum_topic.chat_id = message.chat_id AND name LIKE ? ESCAPE '\'))`, []interface{}{escapeLike(n.Name) + "%"}
+	case FileNameNode:
+		return `message.id IN (SELECT message_id FROM media WHERE file_name LIKE ? ESCAPE '\')`, []interface{}{"%" + escapeLike(n.Text) + "%"}
 	}
-	var messageAndPeer []*MessageAndPeer
-	if err := models.NewQuery(queryMods...).Bind(d.ctx, d.db, &messageAndPeer); err != nil {
-		return nil, err
+	return "1", nil
+}
+
+// normalize folds width, case and chinese variants so indexed text and queries compare with a single condition
+func (d *Database) normalize(text string) string {
+	text = cases.Fold().String(width.Fold.String(text))
+	for _, cc := range []*gocc.OpenCC{d.tw2s, d.hk2s} {
+		converted, err := cc.Convert(text)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		text = converted
 	}
-	return messageAndPeer, nil
+	return text
 }
 
-func (d *Database) UpsertMessage(chatId int64, fromId int64, msgId int64, text string, timestamp int64) error {
+// segment joins the words of normalized text with spaces for the word index
+func (d *Database) segment(text string) string {
+	return strings.Join(d.segmenter.Segment(text), " ")
+}
+
+// UpsertMessage stores a message with its media and entities, media is nil for text messages and
+// formatting holds the formatting entities of the text as json
+func (d *Database) UpsertMessage(chatId int64, fromId int64, msgId int64, text, formatting string, timestamp int64, relations MessageRelations, media *models.Medium, entities []*models.MessageEntity) error {
+	tx, err := d.db.BeginTx(d.ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := d.upsertMessage(tx, chatId, fromId, msgId, text, formatting, timestamp, relations, media, entities); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (d *Database) upsertMessage(exec boil.ContextExecutor, chatId int64, fromId int64, msgId int64, text, formatting string, timestamp int64, relations MessageRelations, media *models.Medium, entities []*models.MessageEntity) error {
+	searchText := d.normalize(text)
 	message := models.Message{
-		ID:        strconv.FormatInt(chatId, 10) + "_" + strconv.FormatInt(msgId, 10),
-		ChatID:    chatId,
-		FromID:    fromId,
-		MSGID:     msgId,
-		Text:      text,
-		Timestamp: time.Unix(timestamp, 0),
+		ID:            strconv.FormatInt(chatId, 10) + "_" + strconv.FormatInt(msgId, 10),
+		ChatID:        chatId,
+		FromID:        fromId,
+		MSGID:         msgId,
+		Text:          text,
+		Formatting:    formatting,
+		Timestamp:     time.Unix(timestamp, 0),
+		HasMedia:      media != nil,
+		SearchText:    null.StringFrom(searchText),
+		SegmentedText: null.StringFrom(d.segment(searchText)),
+
+		ReplyToMSGID:    null.NewInt64(relations.ReplyToMsgId, relations.ReplyToMsgId != 0),
+		MessageThreadID: null.NewInt64(relations.ThreadId, relations.ThreadId != 0),
+		ForwardFromID:   null.NewInt64(relations.ForwardFromId, relations.ForwardFromId != 0),
+		ForwardFromName: relations.ForwardFromName,
+	}
+	if err := message.Upsert(d.ctx, exec, true, []string{"id"}, boil.Blacklist(models.MessageColumns.DeletedAt, models.MessageColumns.DeletedBy), boil.Infer()); err != nil {
+		return err
+	}
+	if media != nil {
+		media.MessageID = message.ID
+		if err := media.Upsert(d.ctx, exec, true, []string{"message_id"}, boil.Infer(), boil.Infer()); err != nil {
+			return err
+		}
+	}
+
+	// entities of edited messages are replaced
+	if _, err := models.MessageEntities(models.MessageEntityWhere.MessageID.EQ(message.ID)).DeleteAll(d.ctx, exec); err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		if !normalizeEntity(entity) {
+			continue
+		}
+		entity.MessageID = message.ID
+		if err := entity.Insert(d.ctx, exec, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeEntity prepares the value and domain of an entity for lookups, false is returned if it is not indexed
+func normalizeEntity(entity *models.MessageEntity) bool {
+	switch entity.Type {
+	case EntityHashtag:
+		entity.Value = strings.ToLower(strings.TrimPrefix(entity.Value, "#"))
+	case EntityMention:
+		entity.Value = strings.ToLower(strings.TrimPrefix(entity.Value, "@"))
+	case EntityTextMention:
+	case EntityURL, EntityTextLink:
+		entity.Domain = urlDomain(entity.Value)
+	default:
+		return false
+	}
+	return entity.Value != ""
+}
+
+// GetReplyChain returns a message and the messages it replies to, oldest first, the chain ends at
+// a message which is not a reply, a message which is missing or deleted or after limit messages
+func (d *Database) GetReplyChain(chatId int64, msgId int64, limit int) ([]*MessageAndPeer, error) {
+	var chain []*MessageAndPeer
+	for len(chain) < limit {
+		var mnp MessageAndPeer
+		err := models.NewQuery(
+			qm.Select(messageAndPeerColumns...),
+			qm.From("message"),
+			qm.InnerJoin("peer on peer.id = message.from_id"),
+			qm.InnerJoin("chat on chat.id = message.chat_id"),
+			qm.LeftOuterJoin("media on media.message_id = message.id"),
+			qm.LeftOuterJoin("forum_topic on forum_topic.chat_id = message.chat_id and forum_topic.thread_id = message.message_thread_id"),
+			models.MessageWhere.ChatID.EQ(chatId),
+			models.MessageWhere.MSGID.EQ(msgId),
+			models.MessageWhere.DeletedAt.IsNull(),
+		).Bind(d.ctx, d.db, &mnp)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]*MessageAndPeer{&mnp}, chain...)
+		// replies to themselves can not be sent, but imports are not trusted
+		if !mnp.ReplyToMSGID.Valid || mnp.ReplyToMSGID.Int64 == msgId {
+			break
+		}
+		msgId = mnp.ReplyToMSGID.Int64
+	}
+	return chain, nil
+}
+
+// GetMessageContext returns up to n messages before and after a message of a chat and the message itself in order
+func (d *Database) GetMessageContext(chatId int64, msgId int64, n int) ([]*MessageAndPeer, error) {
+	queryMods := func(where qm.QueryMod, order string, limit int) []qm.QueryMod {
+		return []qm.QueryMod{
+			qm.Select(messageAndPeerColumns...),
+			qm.From("message"),
+			qm.InnerJoin("peer on peer.id = message.from_id"),
+			qm.InnerJoin("chat on chat.id = message.chat_id"),
+			qm.LeftOuterJoin("media on media.message_id = message.id"),
+			qm.LeftOuterJoin("forum_topic on forum_topic.chat_id = message.chat_id and forum_topic.thread_id = message.message_thread_id"),
+			models.MessageWhere.ChatID.EQ(chatId),
+			models.MessageWhere.DeletedAt.IsNull(),
+			where,
+			qm.OrderBy(order),
+			qm.Limit(limit),
+		}
+	}
+	var before, after []*MessageAndPeer
+	if err := models.NewQuery(queryMods(models.MessageWhere.MSGID.LT(msgId), "message.msg_id DESC", n)...).Bind(d.ctx, d.db, &before); err != nil {
+		return nil, err
+	}
+	if err := models.NewQuery(queryMods(models.MessageWhere.MSGID.GTE(msgId), "message.msg_id", n+1)...).Bind(d.ctx, d.db, &after); err != nil {
+		return nil, err
+	}
+	slices.Reverse(before)
+	return append(before, after...), nil
+}
+
+// UpsertForumTopic stores the name of a forum topic, the thread id of a topic is the id of the message creating it
+func (d *Database) UpsertForumTopic(chatId int64, threadId int64, name string) error {
+	return d.upsertForumTopic(d.db, chatId, threadId, name)
+}
+
+func (d *Database) upsertForumTopic(exec boil.ContextExecutor, chatId int64, threadId int64, name string) error {
+	topic := models.ForumTopic{
+		ChatID:   chatId,
+		ThreadID: threadId,
+		Name:     name,
+	}
+	return topic.Upsert(d.ctx, exec, true, []string{"chat_id", "thread_id"}, boil.Whitelist(models.ForumTopicColumns.Name), boil.Infer())
+}
+
+// GetTopHashtags returns the most used hashtags of a chat
+func (d *Database) GetTopHashtags(chatId int64, limit int) ([]*HashtagCount, error) {
+	var hashtags []*HashtagCount
+	err := models.NewQuery(
+		qm.Select("message_entity.value AS value", "COUNT(*) AS count"),
+		qm.From("message_entity"),
+		qm.InnerJoin("message on message.id = message_entity.message_id"),
+		qm.Where("message_entity.type = ?", EntityHashtag),
+		qm.And("message.chat_id = ?", chatId),
+		qm.And("message.deleted_at IS NULL"),
+		qm.GroupBy("message_entity.value"),
+		qm.OrderBy("count DESC, message_entity.value"),
+		qm.Limit(limit),
+	).Bind(d.ctx, d.db, &hashtags)
+	return hashtags, err
+}
+
+// InsertMessageRevision records an edited version of a message, the original version is recorded
+// on the first edit so the history is complete
+func (d *Database) InsertMessageRevision(chatId int64, msgId int64, text string, editDate int64) error {
+	message, err := models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.MSGID.EQ(msgId), qm.WithDeleted()).One(d.ctx, d.db)
+	if err != nil && err != sql.ErrNoRows {
+		return err
 	}
-	return message.Upsert(d.ctx, d.db, true, []string{"id"}, boil.Blacklist("deleted_at"), boil.Infer())
+	if err == sql.ErrNoRows || message.Text == text {
+		return nil
+	}
+
+	tx, err := d.db.BeginTx(d.ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	count, err := models.MessageRevisions(models.MessageRevisionWhere.MessageID.EQ(message.ID)).Count(d.ctx, tx)
+	if err != nil {
+		return err
+	}
+	revisions := []*models.MessageRevision{}
+	if count <= 0 {
+		revisions = append(revisions, &models.MessageRevision{
+			MessageID:  message.ID,
+			Text:       message.Text,
+			SearchText: d.normalize(message.Text),
+			EditDate:   message.Timestamp,
+		})
+	}
+	revisions = append(revisions, &models.MessageRevision{
+		MessageID:  message.ID,
+		Text:       text,
+		SearchText: d.normalize(text),
+		EditDate:   time.Unix(editDate, 0),
+	})
+	for _, revision := range revisions {
+		if err := revision.Insert(d.ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func (d *Database) DeleteMessage(chatId int64, msgId int64) error {
-	_, err := models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.MSGID.EQ(msgId)).DeleteAll(d.ctx, d.db, false)
+// GetMessageRevisions returns the versions of a message from the oldest
+func (d *Database) GetMessageRevisions(chatId int64, msgId int64) (models.MessageRevisionSlice, error) {
+	return models.MessageRevisions(models.MessageRevisionWhere.MessageID.EQ(strconv.FormatInt(chatId, 10)+"_"+strconv.FormatInt(msgId, 10)), qm.OrderBy("edit_date, id")).All(d.ctx, d.db)
+}
+
+// DeleteMessage hides a message from search until it is restored or purged
+func (d *Database) DeleteMessage(chatId int64, msgId int64, deletedBy int64) error {
+	_, err := models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.MSGID.EQ(msgId)).UpdateAll(d.ctx, d.db, models.M{
+		models.MessageColumns.DeletedAt: dbTime(time.Now()),
+		models.MessageColumns.DeletedBy: deletedBy,
+	})
+	return err
+}
+
+func (d *Database) GetDeletedMessage(chatId int64, msgId int64) (*models.Message, error) {
+	return models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.MSGID.EQ(msgId), models.MessageWhere.DeletedAt.IsNotNull(), qm.WithDeleted()).One(d.ctx, d.db)
+}
+
+func (d *Database) RestoreMessage(chatId int64, msgId int64) error {
+	_, err := models.Messages(models.MessageWhere.ChatID.EQ(chatId), models.MessageWhere.MSGID.EQ(msgId), qm.WithDeleted()).UpdateAll(d.ctx, d.db, models.M{
+		models.MessageColumns.DeletedAt: nil,
+		models.MessageColumns.DeletedBy: nil,
+	})
 	return err
 }
 
+// PurgeDeletedMessages physically removes messages deleted before t
+func (d *Database) PurgeDeletedMessages(t time.Time) (int64, error) {
+	return models.Messages(models.MessageWhere.DeletedAt.LT(null.TimeFrom(dbTime(t))), qm.WithDeleted()).DeleteAll(d.ctx, d.db, true)
+}
+
+// ImportBatch writes imported records in a single transaction, which is committed together with
+// the checkpoint of the chat so a resumed import continues after the last committed batch
+type ImportBatch struct {
+	d  *Database
+	tx *sql.Tx
+}
+
+func (d *Database) BeginImportBatch() (*ImportBatch, error) {
+	tx, err := d.db.BeginTx(d.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportBatch{d: d, tx: tx}, nil
+}
+
+// UpsertPeer stores a peer of an export, the username is only updated when the export provides one
+// since exports carry none and a username stored by the bot would be wiped
+func (b *ImportBatch) UpsertPeer(peerId int64, fullName, username string) error {
+	updateColumns := []string{models.PeerColumns.FullName}
+	if username != "" {
+		updateColumns = append(updateColumns, models.PeerColumns.Username)
+	}
+	return b.d.upsertPeer(b.tx, peerId, fullName, username, updateColumns...)
+}
+
+func (b *ImportBatch) UpsertForumTopic(chatId int64, threadId int64, name string) error {
+	return b.d.upsertForumTopic(b.tx, chatId, threadId, name)
+}
+
+func (b *ImportBatch) InsertChatPeer(chatId int64, peerId int64) error {
+	return b.d.insertChatPeer(b.tx, chatId, peerId)
+}
+
+func (b *ImportBatch) DeleteChatPeer(chatId int64, peerId int64) error {
+	return b.d.deleteChatPeer(b.tx, chatId, peerId)
+}
+
+func (b *ImportBatch) GetPeerIdByFullName(chatId int64, fullName string) (int64, error) {
+	return b.d.getPeerIdByFullName(b.tx, chatId, fullName)
+}
+
+func (b *ImportBatch) UpsertMessage(chatId int64, fromId int64, msgId int64, text, formatting string, timestamp int64, relations MessageRelations, media *models.Medium, entities []*models.MessageEntity) error {
+	return b.d.upsertMessage(b.tx, chatId, fromId, msgId, text, formatting, timestamp, relations, media, entities)
+}
+
+// Commit records lastMsgId as the checkpoint of a chat in source and commits the batch
+func (b *ImportBatch) Commit(chatId int64, source string, lastMsgId int64) error {
+	checkpoint := models.ImportCheckpoint{
+		ChatID:    chatId,
+		Source:    source,
+		LastMSGID: lastMsgId,
+	}
+	if err := checkpoint.Upsert(b.d.ctx, b.tx, true, []string{"chat_id", "source"}, boil.Whitelist(models.ImportCheckpointColumns.LastMSGID, models.ImportCheckpointColumns.UpdatedAt), boil.Infer()); err != nil {
+		return err
+	}
+	return b.tx.Commit()
+}
+
+func (b *ImportBatch) Rollback() error {
+	return b.tx.Rollback()
+}
+
+// placeholderPeerIdMax is the highest id of the placeholder peers of imported authors which are only
+// known by their name, the range is far below the -100 prefixed ids of channels and is left out of
+// the peer lookups
+const placeholderPeerIdMax = -(int64(1) << 52)
+
+// GetPlaceholderPeers returns the placeholder peers of imported authors
+func (d *Database) GetPlaceholderPeers() (models.PeerSlice, error) {
+	return models.Peers(models.PeerWhere.ID.LTE(placeholderPeerIdMax)).All(d.ctx, d.db)
+}
+
+// GetPeerIdByFullName returns the id of the member of a chat with the full name, or the id of the
+// only peer with it, and 0 if the name is unknown or ambiguous
+func (d *Database) GetPeerIdByFullName(chatId int64, fullName string) (int64, error) {
+	return d.getPeerIdByFullName(d.db, chatId, fullName)
+}
+
+func (d *Database) getPeerIdByFullName(exec boil.ContextExecutor, chatId int64, fullName string) (int64, error) {
+	peers, err := models.Peers(models.PeerWhere.FullName.EQ(fullName), models.PeerWhere.ID.GT(placeholderPeerIdMax), qm.Limit(100)).All(d.ctx, exec)
+	if err != nil {
+		return 0, err
+	}
+	var peerId int64
+	for _, peer := range peers {
+		if _, err := d.getChatPeers(exec, chatId, peer.ID); err == nil {
+			if peerId != 0 {
+				return 0, nil
+			}
+			peerId = peer.ID
+		} else if err != sql.ErrNoRows {
+			return 0, err
+		}
+	}
+	if peerId == 0 && len(peers) == 1 {
+		peerId = peers[0].ID
+	}
+	return peerId, nil
+}
+
+// GetImportCheckpoint returns the id of the last message of a chat imported from source, or 0
+func (d *Database) GetImportCheckpoint(chatId int64, source string) (int64, error) {
+	checkpoint, err := models.FindImportCheckpoint(d.ctx, d.db, chatId, source)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return checkpoint.LastMSGID, nil
+}
+
 func (d *Database) GetChatPeersCount(peerId int64) (int64, error) {
 	return models.ChatPeers(models.ChatPeerWhere.PeerID.EQ(peerId)).Count(d.ctx, d.db)
 }
@@ -264,16 +1440,29 @@ func (d *Database) GetChatPeersFromPeerId(peerId int64) ([]*models.ChatPeer, err
 	return models.ChatPeers(models.ChatPeerWhere.PeerID.EQ(peerId)).All(d.ctx, d.db)
 }
 
+// GetChatMembers returns the peers which are stored as members of a chat
+func (d *Database) GetChatMembers(chatId int64) (models.PeerSlice, error) {
+	return models.Peers(qm.InnerJoin("chat_peer on chat_peer.peer_id = peer.id"), qm.Where("chat_peer.chat_id = ?", chatId)).All(d.ctx, d.db)
+}
+
 func (d *Database) GetChatPeerCount(chatId int64, peerId int64) (int64, error) {
 	return models.ChatPeers(models.ChatPeerWhere.ChatID.EQ(chatId), models.ChatPeerWhere.PeerID.EQ(peerId)).Count(d.ctx, d.db)
 }
 
 func (d *Database) GetChatPeers(chatId int64, peerId int64) (*models.ChatPeer, error) {
-	return models.ChatPeers(models.ChatPeerWhere.ChatID.EQ(chatId), models.ChatPeerWhere.PeerID.EQ(peerId)).One(d.ctx, d.db)
+	return d.getChatPeers(d.db, chatId, peerId)
+}
+
+func (d *Database) getChatPeers(exec boil.ContextExecutor, chatId int64, peerId int64) (*models.ChatPeer, error) {
+	return models.ChatPeers(models.ChatPeerWhere.ChatID.EQ(chatId), models.ChatPeerWhere.PeerID.EQ(peerId)).One(d.ctx, exec)
 }
 
 func (d *Database) InsertChatPeer(chatId int64, peerId int64) error {
-	_, err := d.GetChatPeers(chatId, peerId)
+	return d.insertChatPeer(d.db, chatId, peerId)
+}
+
+func (d *Database) insertChatPeer(exec boil.ContextExecutor, chatId int64, peerId int64) error {
+	_, err := d.getChatPeers(exec, chatId, peerId)
 	if err != nil && err != sql.ErrNoRows {
 		return err
 	}
@@ -284,10 +1473,14 @@ func (d *Database) InsertChatPeer(chatId int64, peerId int64) error {
 		ChatID: chatId,
 		PeerID: peerId,
 	}
-	return chatPeer.Insert(d.ctx, d.db, boil.Infer())
+	return chatPeer.Insert(d.ctx, exec, boil.Infer())
 }
 
 func (d *Database) DeleteChatPeer(chatId int64, peerId int64) error {
-	_, err := models.ChatPeers(models.ChatPeerWhere.ChatID.EQ(chatId), models.ChatPeerWhere.PeerID.EQ(peerId)).DeleteAll(d.ctx, d.db)
+	return d.deleteChatPeer(d.db, chatId, peerId)
+}
+
+func (d *Database) deleteChatPeer(exec boil.ContextExecutor, chatId int64, peerId int64) error {
+	_, err := models.ChatPeers(models.ChatPeerWhere.ChatID.EQ(chatId), models.ChatPeerWhere.PeerID.EQ(peerId)).DeleteAll(d.ctx, exec)
 	return err
 }
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestDatabase opens a copy of the empty database shipped with the repo
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+	data, err := os.ReadFile("data.db.bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	databaseFile := filepath.Join(t.TempDir(), "data.db")
+	if err := os.WriteFile(databaseFile, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewDatabase(databaseFile, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+func TestSearchShortChineseTerms(t *testing.T) {
+	d := newTestDatabase(t)
+	chatId := int64(-1001234567890)
+	if err := d.UpsertChat(chatId, "My Group", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.UpsertPeer(114514, "Alice", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	for i, text := range []string{"今天天气很好", "你好世界", "你们好", "hello world"} {
+		if err := d.UpsertMessage(chatId, 114514, int64(i+1), text, "", 1710000000+int64(i), MessageRelations{}, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query  string
+		msgIds []int64
+	}{
+		{"你好", []int64{2}},
+		{"天气", []int64{1}},
+		{"你们", []int64{3}},
+		{"你好 OR 天气", []int64{2, 1}},
+		{"-你好 好", []int64{3, 1}},
+	}
+	for _, test := range tests {
+		for _, sortOrder := range []string{SortOrderTime, SortOrderRelevance} {
+			query, err := ParseSearchQuery(test.query, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			query.SortOrder = sortOrder
+			results, _, err := d.SearchMessages([]int64{chatId}, query, nil)
+			if err != nil {
+				t.Fatalf("search %q: %v", test.query, err)
+			}
+			var msgIds []int64
+			for _, result := range results {
+				msgIds = append(msgIds, result.Message.MSGID)
+			}
+			if !reflect.DeepEqual(msgIds, test.msgIds) {
+				t.Errorf("search %q sorted by %s = %v, want %v", test.query, sortOrder, msgIds, test.msgIds)
+			}
+		}
+		query, err := ParseSearchQuery(test.query, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		count, _, err := d.CountMessages([]int64{chatId}, query, 100)
+		if err != nil {
+			t.Fatalf("count %q: %v", test.query, err)
+		}
+		if count != len(test.msgIds) {
+			t.Errorf("count %q = %d, want %d", test.query, count, len(test.msgIds))
+		}
+	}
+}
+
+func TestParseSearchCursor(t *testing.T) {
+	tests := []struct {
+		offset string
+		cursor *SearchCursor
+	}{
+		{"", nil},
+		{"1710000000_42", &SearchCursor{Timestamp: 1710000000, RowId: 42}},
+		{"o50", &SearchCursor{Offset: 50}},
+	}
+	for _, test := range tests {
+		cursor, err := ParseSearchCursor(test.offset)
+		if err != nil {
+			t.Errorf("ParseSearchCursor(%q): %v", test.offset, err)
+			continue
+		}
+		if !reflect.DeepEqual(cursor, test.cursor) {
+			t.Errorf("ParseSearchCursor(%q) = %+v, want %+v", test.offset, cursor, test.cursor)
+		}
+		if cursor != nil && cursor.String() != test.offset {
+			t.Errorf("ParseSearchCursor(%q).String() = %q", test.offset, cursor.String())
+		}
+	}
+}
+
+func TestParseSearchCursorErrors(t *testing.T) {
+	for _, offset := range []string{"42", "o", "ofifty", "1710000000_", "_42", "abc_42", "1710000000_42_1"} {
+		if cursor, err := ParseSearchCursor(offset); err == nil {
+			t.Errorf("ParseSearchCursor(%q) = %+v, want an error", offset, cursor)
+		}
+	}
+}
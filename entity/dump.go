@@ -1,5 +1,10 @@
 package entity
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type Dump struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
@@ -13,7 +18,65 @@ type Message struct {
 	DateUnixTime string  `json:"date_unixtime"`
 	From         string  `json:"from"`
 	FromId       string  `json:"from_id"`
-	FullText     string  `json:"full_text"`
+	FullText     Text    `json:"full_text"`
+	Text         Text    `json:"text"`
+	Date         string  `json:"date"`
 	Photo        *string `json:"photo"`
+	File         *string `json:"file"`
 	MediaType    *string `json:"media_type"`
+
+	ReplyToMessageId int64  `json:"reply_to_message_id"`
+	ForwardedFrom    string `json:"forwarded_from"`
+
+	Action  string   `json:"action"`
+	Title   string   `json:"title"`
+	Actor   string   `json:"actor"`
+	ActorId string   `json:"actor_id"`
+	Members []string `json:"members"`
+
+	PhotoFileSize   int64  `json:"photo_file_size"`
+	FileName        string `json:"file_name"`
+	FileSize        int64  `json:"file_size"`
+	MimeType        string `json:"mime_type"`
+	DurationSeconds int64  `json:"duration_seconds"`
+
+	TextEntities []TextEntity `json:"text_entities"`
+}
+
+// Text is the text of an exported message, which newer exports write as an array of plain strings
+// and text entity objects instead of a string
+type Text string
+
+func (t *Text) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = Text(s)
+		return nil
+	}
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for _, part := range parts {
+		if err := json.Unmarshal(part, &s); err == nil {
+			sb.WriteString(s)
+			continue
+		}
+		var entity TextEntity
+		if err := json.Unmarshal(part, &entity); err != nil {
+			return err
+		}
+		sb.WriteString(entity.Text)
+	}
+	*t = Text(sb.String())
+	return nil
+}
+
+type TextEntity struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Href     string `json:"href"`
+	UserId   int64  `json:"user_id"`
+	Language string `json:"language"`
 }
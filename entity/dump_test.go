@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTextUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Text
+	}{
+		{`"hello world"`, "hello world"},
+		{`""`, ""},
+		{`[]`, ""},
+		{`["see ", {"type": "link", "text": "https://go.dev"}, " and ", {"type": "bold", "text": "read"}, " it"]`, "see https://go.dev and read it"},
+		{`[{"type": "mention", "text": "@alice"}]`, "@alice"},
+	}
+	for _, test := range tests {
+		var text Text
+		if err := json.Unmarshal([]byte(test.json), &text); err != nil {
+			t.Errorf("unmarshal %s: %v", test.json, err)
+			continue
+		}
+		if text != test.want {
+			t.Errorf("unmarshal %s = %q, want %q", test.json, text, test.want)
+		}
+	}
+	for _, data := range []string{`42`, `{"text": "hello"}`, `["hello", 42]`} {
+		var text Text
+		if err := json.Unmarshal([]byte(data), &text); err == nil {
+			t.Errorf("unmarshal %s = %q, want an error", data, text)
+		}
+	}
+}
+
+func TestMessageText(t *testing.T) {
+	var msg Message
+	if err := json.Unmarshal([]byte(`{"id": 1, "text": ["hi ", {"type": "mention", "text": "@alice"}], "full_text": "hi @alice!"}`), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Text != "hi @alice" || msg.FullText != "hi @alice!" {
+		t.Errorf("got text %q and full text %q", msg.Text, msg.FullText)
+	}
+}
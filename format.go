@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// formattingTypes are the entity types kept when a message is sent again, links, mentions and
+// hashtags are detected by clients anyway and quotes can not be nested into the result quote
+var formattingTypes = map[string]bool{
+	"bold":          true,
+	"italic":        true,
+	"underline":     true,
+	"strikethrough": true,
+	"spoiler":       true,
+	"code":          true,
+	"pre":           true,
+	"text_link":     true,
+	"text_mention":  true,
+}
+
+// encodeFormatting returns the formatting entities of a message as json for the formatting column
+func encodeFormatting(entities []gotgbot.MessageEntity) string {
+	var kept []gotgbot.MessageEntity
+	for _, entity := range entities {
+		if formattingTypes[entity.Type] {
+			kept = append(kept, entity)
+		}
+	}
+	if len(kept) <= 0 {
+		return ""
+	}
+	formatting, err := json.Marshal(kept)
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+	return string(formatting)
+}
+
+func decodeFormatting(formatting string) []gotgbot.MessageEntity {
+	if formatting == "" {
+		return nil
+	}
+	var entities []gotgbot.MessageEntity
+	if err := json.Unmarshal([]byte(formatting), &entities); err != nil {
+		log.Println(err)
+		return nil
+	}
+	return entities
+}
+
+// utf16Len returns the length of s in UTF-16 code units, the unit of entity offsets
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r > 0xffff && r <= utf8.MaxRune {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// entityBuilder builds a text with entities, offsets are counted in UTF-16 code units
+type entityBuilder struct {
+	text     strings.Builder
+	length   int64
+	entities []gotgbot.MessageEntity
+}
+
+func (b *entityBuilder) WriteString(s string) {
+	b.text.WriteString(s)
+	b.length += int64(utf16Len(s))
+}
+
+// writeSnippet appends text between the byte offsets start and end with ellipses on trimmed sides,
+// the entities are clipped to the snippet and the ranges are made bold unless they touch code
+func (b *entityBuilder) writeSnippet(text string, start, end int, entities []gotgbot.MessageEntity, ranges []matchRange) {
+	if start > 0 {
+		b.WriteString("…")
+	}
+	base := b.length
+	startUnits := int64(utf16Len(text[:start]))
+	endUnits := startUnits + int64(utf16Len(text[start:end]))
+	clip := func(offset, length int64) (int64, int64, bool) {
+		from, to := max(offset, startUnits), min(offset+length, endUnits)
+		return base + from - startUnits, to - from, to > from
+	}
+
+	var code [][2]int64
+	for _, entity := range entities {
+		offset, length, ok := clip(entity.Offset, entity.Length)
+		if !ok {
+			continue
+		}
+		entity.Offset, entity.Length = offset, length
+		b.entities = append(b.entities, entity)
+		if entity.Type == "code" || entity.Type == "pre" {
+			code = append(code, [2]int64{offset, offset + length})
+		}
+	}
+ranges:
+	for _, r := range ranges {
+		from := int64(utf16Len(text[:r.start]))
+		offset, length, ok := clip(from, int64(utf16Len(text[r.start:r.end])))
+		if !ok {
+			continue
+		}
+		for _, c := range code {
+			if offset < c[1] && offset+length > c[0] {
+				continue ranges
+			}
+		}
+		b.entities = append(b.entities, gotgbot.MessageEntity{Type: "bold", Offset: offset, Length: length})
+	}
+
+	b.WriteString(text[start:end])
+	if end < len(text) {
+		b.WriteString("…")
+	}
+}
+
+// writeVia appends a link to the original message on a new line
+func (b *entityBuilder) writeVia(username string, chatId, threadId, msgId int64, fullName string) {
+	if b.length > 0 {
+		b.WriteString("\n")
+	}
+	via := "Via " + fullName
+	b.entities = append(b.entities, gotgbot.MessageEntity{Type: "text_link", Offset: b.length, Length: int64(utf16Len(via)), Url: generateTelegramLink(username, chatId, threadId, msgId)})
+	b.WriteString(via)
+}
+
+// formatConversation renders messages as a single message, every message has a header linking to it
+// and a snippet of its text which gets shorter the more messages there are, the header of target is bold
+func formatConversation(messages []*MessageAndPeer, target int64, loc *time.Location) (string, []gotgbot.MessageEntity, error) {
+	size := 512
+	if len(messages) > 0 {
+		size = max(min(size, 2560/len(messages)), 64)
+	}
+	var b entityBuilder
+	for i, mnp := range messages {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		header := mnp.FullName
+		if mnp.ForwardFromName != "" {
+			header += " (forwarded from " + mnp.ForwardFromName + ")"
+		}
+		b.entities = append(b.entities, gotgbot.MessageEntity{Type: "text_link", Offset: b.length, Length: int64(utf16Len(header)), Url: generateTelegramLink(mnp.ChatUsername, mnp.Message.ChatID, mnp.MessageThreadID.Int64, mnp.MSGID)})
+		if mnp.MSGID == target {
+			b.entities = append(b.entities, gotgbot.MessageEntity{Type: "bold", Offset: b.length, Length: int64(utf16Len(header))})
+		}
+		b.WriteString(header)
+		b.WriteString(" · " + mnp.Timestamp.In(loc).Format(time.DateTime) + "\n")
+
+		text := mnp.DisplayText()
+		start, end, err := snippetWindow(text, nil, size)
+		if err != nil {
+			return "", nil, err
+		}
+		var entities []gotgbot.MessageEntity
+		if text == mnp.Text {
+			entities = decodeFormatting(mnp.Formatting)
+		}
+		b.writeSnippet(text, start, end, entities, nil)
+	}
+	return b.text.String(), b.entities, nil
+}
+
+// formatResult renders a snippet of at most size UTF-16 code units of text with the original formatting,
+// the matches in bold and the via link, quote puts the snippet into an expandable quote
+func formatResult(mnp *MessageAndPeer, text string, ranges []matchRange, size int, quote bool) (string, []gotgbot.MessageEntity, error) {
+	start, end, err := snippetWindow(text, ranges, size)
+	if err != nil {
+		return "", nil, err
+	}
+	var b entityBuilder
+	// media labels have no formatting
+	var entities []gotgbot.MessageEntity
+	if text == mnp.Text {
+		entities = decodeFormatting(mnp.Formatting)
+	}
+	b.writeSnippet(text, start, end, entities, ranges)
+	if quote && b.length > 0 {
+		b.entities = append([]gotgbot.MessageEntity{{Type: "expandable_blockquote", Offset: 0, Length: b.length}}, b.entities...)
+	}
+	b.writeVia(mnp.ChatUsername, mnp.Message.ChatID, mnp.MessageThreadID.Int64, mnp.MSGID, mnp.FullName)
+	return b.text.String(), b.entities, nil
+}
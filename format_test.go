@@ -0,0 +1,99 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/JasonKhew96/telegram-search-bot-go/models"
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+func TestUtf16Len(t *testing.T) {
+	tests := map[string]int{
+		"":        0,
+		"hello":   5,
+		"你好":      2,
+		"😀":       2,
+		"👍🏽":      4,
+		"a😀b":     4,
+		"👨‍👩‍👧‍👦": 11,
+	}
+	for text, want := range tests {
+		if got := utf16Len(text); got != want {
+			t.Errorf("utf16Len(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestFormatResult(t *testing.T) {
+	link := generateTelegramLink("", -1001234, 0, 42)
+	tests := []struct {
+		name       string
+		text       string
+		formatting []gotgbot.MessageEntity
+		match      string
+		size       int
+		quote      bool
+		want       string
+		entities   []gotgbot.MessageEntity
+	}{
+		{
+			name:       "whole text in a quote",
+			text:       "😀😀 say hello to 👍🏽 world",
+			formatting: []gotgbot.MessageEntity{{Type: "italic", Offset: 9, Length: 5}, {Type: "spoiler", Offset: 18, Length: 4}},
+			match:      "hello",
+			size:       100,
+			quote:      true,
+			want:       "😀😀 say hello to 👍🏽 world\nVia Alice",
+			entities: []gotgbot.MessageEntity{
+				{Type: "expandable_blockquote", Offset: 0, Length: 28},
+				{Type: "italic", Offset: 9, Length: 5},
+				{Type: "spoiler", Offset: 18, Length: 4},
+				{Type: "bold", Offset: 9, Length: 5},
+				{Type: "text_link", Offset: 29, Length: 9, Url: link},
+			},
+		},
+		{
+			name:       "trimmed around the match",
+			text:       strings.Repeat("😀", 20) + "hello" + strings.Repeat("😀", 20),
+			formatting: []gotgbot.MessageEntity{{Type: "italic", Offset: 0, Length: 85}, {Type: "code", Offset: 0, Length: 2}},
+			match:      "hello",
+			size:       9,
+			want:       "…😀hello😀…\nVia Alice",
+			entities: []gotgbot.MessageEntity{
+				{Type: "italic", Offset: 1, Length: 9},
+				{Type: "bold", Offset: 3, Length: 5},
+				{Type: "text_link", Offset: 12, Length: 9, Url: link},
+			},
+		},
+		{
+			name:       "match in code is not bold",
+			text:       "run go test now",
+			formatting: []gotgbot.MessageEntity{{Type: "code", Offset: 4, Length: 7}},
+			match:      "test",
+			size:       100,
+			want:       "run go test now\nVia Alice",
+			entities: []gotgbot.MessageEntity{
+				{Type: "code", Offset: 4, Length: 7},
+				{Type: "text_link", Offset: 16, Length: 9, Url: link},
+			},
+		},
+	}
+	for _, test := range tests {
+		mnp := &MessageAndPeer{
+			Message: models.Message{ChatID: -1001234, MSGID: 42, Text: test.text, Formatting: encodeFormatting(test.formatting)},
+			Peer:    models.Peer{FullName: "Alice"},
+		}
+		text, entities, err := formatResult(mnp, test.text, []matchRange{rangeOf(test.text, test.match)}, test.size, test.quote)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text != test.want {
+			t.Errorf("%s: text = %q, want %q", test.name, text, test.want)
+		}
+		if !reflect.DeepEqual(entities, test.entities) {
+			t.Errorf("%s: entities = %+v, want %+v", test.name, entities, test.entities)
+		}
+	}
+}
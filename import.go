@@ -2,128 +2,787 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/JasonKhew96/telegram-search-bot-go/entity"
+	"github.com/JasonKhew96/telegram-search-bot-go/models"
+	"github.com/PaulSonOfLars/gotgbot/v2"
 )
 
-func importData(databaseFile, importFile string) {
-	db, err := NewDatabase(databaseFile, true)
+// exportMediaTypes maps the media_type of tdesktop exports to media types
+var exportMediaTypes = map[string]string{
+	"animation":     MediaAnimation,
+	"audio_file":    MediaAudio,
+	"sticker":       MediaSticker,
+	"video_file":    MediaVideo,
+	"video_message": MediaVideoNote,
+	"voice_message": MediaVoice,
+}
+
+// entitiesFromExport returns the indexed entities of an exported message
+func entitiesFromExport(msg *entity.Message) []*models.MessageEntity {
+	var entities []*models.MessageEntity
+	for _, e := range msg.TextEntities {
+		switch e.Type {
+		case "hashtag":
+			entities = append(entities, &models.MessageEntity{Type: EntityHashtag, Value: e.Text})
+		case "mention":
+			entities = append(entities, &models.MessageEntity{Type: EntityMention, Value: e.Text})
+		case "mention_name":
+			entities = append(entities, &models.MessageEntity{Type: EntityTextMention, Value: strconv.FormatInt(e.UserId, 10)})
+		case "link":
+			entities = append(entities, &models.MessageEntity{Type: EntityURL, Value: e.Text})
+		case "text_link":
+			entities = append(entities, &models.MessageEntity{Type: EntityTextLink, Value: e.Href})
+		}
+	}
+	return entities
+}
+
+// exportFormattingTypes maps the text entity types of tdesktop exports to formatting entity types
+var exportFormattingTypes = map[string]string{
+	"bold":          "bold",
+	"italic":        "italic",
+	"underline":     "underline",
+	"strikethrough": "strikethrough",
+	"spoiler":       "spoiler",
+	"code":          "code",
+	"pre":           "pre",
+	"text_link":     "text_link",
+	"mention_name":  "text_mention",
+}
+
+// formattingFromExport returns the formatting of an exported message, exports list the text entities
+// in order without offsets, so the offsets are the lengths of the preceding entities
+func formattingFromExport(msg *entity.Message) string {
+	var entities []gotgbot.MessageEntity
+	offset := int64(0)
+	for _, e := range msg.TextEntities {
+		length := int64(utf16Len(e.Text))
+		if entityType, ok := exportFormattingTypes[e.Type]; ok && length > 0 {
+			entity := gotgbot.MessageEntity{Type: entityType, Offset: offset, Length: length, Url: e.Href, Language: e.Language}
+			if e.UserId != 0 {
+				entity.User = &gotgbot.User{Id: e.UserId, FirstName: e.Text}
+			}
+			entities = append(entities, entity)
+		}
+		offset += length
+	}
+	return encodeFormatting(entities)
+}
+
+// relationsFromExport returns the reply and forward origin of an exported message, exports only
+// have the name of the forward origin
+func relationsFromExport(msg *entity.Message) MessageRelations {
+	return MessageRelations{
+		ReplyToMsgId:    msg.ReplyToMessageId,
+		ForwardFromName: msg.ForwardedFrom,
+	}
+}
+
+// mediaFromExport returns the media of an exported message, exports have no file ids
+func mediaFromExport(msg *entity.Message) *models.Medium {
+	if msg.Photo != nil {
+		return &models.Medium{
+			Type:     MediaPhoto,
+			FileSize: msg.PhotoFileSize,
+		}
+	}
+	if msg.File == nil && msg.MediaType == nil {
+		return nil
+	}
+	media := &models.Medium{
+		Type:     MediaDocument,
+		MimeType: msg.MimeType,
+		FileSize: msg.FileSize,
+		Duration: msg.DurationSeconds,
+	}
+	if msg.MediaType != nil {
+		if mediaType, ok := exportMediaTypes[*msg.MediaType]; ok {
+			media.Type = mediaType
+		}
+	}
+	// files which were not exported have a placeholder in parentheses as their path
+	if msg.FileName != "" {
+		media.FileName = msg.FileName
+	} else if msg.File != nil && !strings.HasPrefix(*msg.File, "(") {
+		media.FileName = path.Base(*msg.File)
+	}
+	return media
+}
+
+// exportChatTypes are the chat types of tdesktop exports imported when no chats are selected,
+// private chats with users and bots are left out, the chat of a single chat export is always imported
+var exportChatTypes = map[string]bool{
+	"private_group":      true,
+	"private_supergroup": true,
+	"public_supergroup":  true,
+	"private_channel":    true,
+	"public_channel":     true,
+}
+
+// exportChatId converts the bare id of an exported chat to the chat id of the messages, which is the
+// bot chat id of supergroups and channels. Every chat type gets the -100 prefix like in earlier imports,
+// so chats imported before are not split when they are imported again
+func exportChatId(id int64) int64 {
+	return convert2BotChatId(id)
+}
+
+// importBatchSize is the number of records written in a transaction before the checkpoint is committed
+const importBatchSize = 1000
+
+// importProgressInterval is the interval of the progress line
+const importProgressInterval = 5 * time.Second
+
+// importer streams tdesktop exports into the database
+type importer struct {
+	db *Database
+	// dryRun only parses the export and reports what would be imported
+	dryRun bool
+	report importReport
+	// source identifies the file in checkpoints
+	source string
+	// selection holds chat ids and lower cased names, groups and channels are imported if it is empty
+	selection  map[string]bool
+	cachedPeer map[int64]struct{}
+	// members adds chat members from the authors and member service messages of the export
+	members bool
+	// peerNames maps the names of the authors and actors seen so far to their ids, service messages
+	// only have the names of added and removed members
+	peerNames map[string]int64
+	// chatPeers are the members of the current chat which are known to be stored
+	chatPeers map[int64]bool
+	// placeholders maps the names of html export authors without a known peer to their placeholder
+	// peers, lastPlaceholder is the lowest placeholder id in use
+	placeholders    map[string]int64
+	lastPlaceholder int64
+	batch           *ImportBatch
+	// totals of the current chat
+	totals chatTotals
+
+	// progress is estimated from the read offset in the import files
+	size         int64
+	offset       int64
+	started      time.Time
+	lastProgress time.Time
+	processed    int
+
+	chats    int
+	messages int
+}
+
+// chatTotals are the per chat counts reported after a chat is imported
+type chatTotals struct {
+	imported int
+	skipped  int
+	// resumed counts the records up to the checkpoint of a previous run
+	resumed int
+	// invalid counts the records which can not be imported
+	invalid int
+}
+
+// importReport collects the statistics of a dry run
+type importReport struct {
+	types         map[string]int
+	invalid       map[string]int
+	examples      map[string][]string
+	unknownFields map[string]int
+}
+
+// importReportExamples is the number of records listed per reason of a dry run
+const importReportExamples = 5
+
+func (r *importReport) print() {
+	log.Println("records by type:")
+	for _, key := range sortedKeys(r.types) {
+		log.Printf("  %s: %d", key, r.types[key])
+	}
+	log.Println("skipped records:")
+	for _, key := range sortedKeys(r.invalid) {
+		log.Printf("  %s: %d (%s)", key, r.invalid[key], strings.Join(r.examples[key], ", "))
+	}
+	log.Println("unknown fields:")
+	for _, key := range sortedKeys(r.unknownFields) {
+		log.Printf("  %s: %d", key, r.unknownFields[key])
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// exportFields are the fields of exported messages which are read or known to be irrelevant,
+// others are reported as unknown by a dry run
+var exportFields = func() map[string]bool {
+	fields := map[string]bool{}
+	for _, field := range []string{
+		"edited", "edited_unixtime", "inviter", "width", "height",
+		"thumbnail", "thumbnail_file_size", "sticker_emoji", "performer", "via_bot", "saved_from",
+		"author", "signature", "reactions", "inline_bot_buttons", "message_id", "reply_to_peer_id",
+		"contact_information", "contact_vcard", "location_information", "live_location_period_seconds",
+		"place_name", "address", "poll", "game_title", "game_description", "game_link",
+		"invoice_information", "self_destruct_period_seconds", "media_spoiler", "new_title",
+		"new_icon_emoji_id", "duration", "discard_reason", "schedule_date", "boosts",
+	} {
+		fields[field] = true
+	}
+	t := reflect.TypeOf(entity.Message{})
+	for i := 0; i < t.NumField(); i++ {
+		fields[strings.Split(t.Field(i).Tag.Get("json"), ",")[0]] = true
+	}
+	return fields
+}()
+
+func importData(databaseFile, importFile, dictionaryFile, chats string, dryRun, members bool) {
+	// a dry run does not open the database, it would apply the migrations
+	var db *Database
+	if !dryRun {
+		var err error
+		db, err = NewDatabase(databaseFile, dictionaryFile, true)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer db.Close()
+	}
+
+	stat, err := os.Stat(importFile)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	source, err := filepath.Abs(importFile)
 	if err != nil {
 		log.Fatalln(err)
 	}
-	defer db.Close()
 
-	if _, err := os.Stat(importFile); err != nil {
+	im := &importer{
+		db:     db,
+		dryRun: dryRun,
+		report: importReport{
+			types:         make(map[string]int),
+			invalid:       make(map[string]int),
+			examples:      make(map[string][]string),
+			unknownFields: make(map[string]int),
+		},
+		source:       source,
+		selection:    make(map[string]bool),
+		cachedPeer:   make(map[int64]struct{}),
+		members:      members,
+		peerNames:    make(map[string]int64),
+		started:      time.Now(),
+		lastProgress: time.Now(),
+	}
+	for _, chat := range strings.Split(chats, ",") {
+		if chat = strings.TrimSpace(chat); chat != "" {
+			im.selection[strings.ToLower(chat)] = true
+		}
+	}
+
+	// html exports are a directory with messages.html, messages2.html, ...
+	if stat.IsDir() || strings.EqualFold(filepath.Ext(importFile), ".html") {
+		err = im.importHTML(importFile)
+	} else {
+		err = im.importJSON(importFile)
+	}
+	if err != nil {
 		log.Fatalln(err)
 	}
+	if dryRun {
+		im.report.print()
+		log.Printf("dry run: %d messages of %d chats would be imported", im.messages, im.chats)
+		return
+	}
+	log.Printf("imported %d messages of %d chats in %s", im.messages, im.chats, time.Since(im.started).Round(time.Second))
+}
+
+func (im *importer) importJSON(importFile string) error {
 	f, err := os.Open(importFile)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
 	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	im.size = stat.Size()
+	return im.importFile(json.NewDecoder(f))
+}
 
-	dec := json.NewDecoder(f)
-
+// importFile reads both the export of a single chat, which is a chat object, and the export of
+// a whole account, which lists the chats in chats.list and left_chats.list
+func (im *importer) importFile(dec *json.Decoder) error {
 	var dump entity.Dump
+	return readObject(dec, func(key string) error {
+		switch key {
+		case "chats", "left_chats":
+			return readObject(dec, func(key string) error {
+				if key != "list" {
+					return skipValue(dec)
+				}
+				return readArray(dec, func() error {
+					var dump entity.Dump
+					return readObject(dec, func(key string) error {
+						return im.readChatField(dec, &dump, key, false)
+					})
+				})
+			})
+		}
+		return im.readChatField(dec, &dump, key, true)
+	})
+}
+
+// readChatField reads a field of a chat object, exports write the messages after the name, type and id
+// of a chat so they are imported while they are read. single is set for the chat of a single chat export
+func (im *importer) readChatField(dec *json.Decoder, dump *entity.Dump, key string, single bool) error {
+	switch key {
+	case "name":
+		return dec.Decode(&dump.Name)
+	case "type":
+		return dec.Decode(&dump.Type)
+	case "id":
+		var id int64
+		if err := dec.Decode(&id); err != nil {
+			return err
+		}
+		dump.Id = exportChatId(id)
+		return nil
+	case "messages":
+		if !im.selected(dump, single) {
+			log.Printf("skipping chat %d %q", dump.Id, dump.Name)
+			return skipValue(dec)
+		}
+		return im.importChat(dump, func(record func(msg *entity.Message) error) error {
+			return readArray(dec, func() error {
+				// records are decoded separately so a malformed record only skips itself
+				var raw json.RawMessage
+				if err := dec.Decode(&raw); err != nil {
+					return err
+				}
+				im.offset = dec.InputOffset()
+				var msg entity.Message
+				if err := json.Unmarshal(raw, &msg); err != nil {
+					im.processed++
+					im.totals.invalid++
+					im.skip(dump, fmt.Sprintf("record %d", im.processed), "malformed record: "+err.Error())
+					return nil
+				}
+				if im.dryRun {
+					im.inspect(&msg, raw)
+				}
+				return record(&msg)
+			})
+		})
+	}
+	return skipValue(dec)
+}
 
-	count := 0
+// selected reports whether a chat is imported, chats are selected by their bot chat id, their id in
+// the export or their name
+func (im *importer) selected(dump *entity.Dump, single bool) bool {
+	if dump.Id == 0 {
+		return false
+	}
+	if len(im.selection) <= 0 {
+		return single || exportChatTypes[dump.Type]
+	}
+	return im.selection[strconv.FormatInt(dump.Id, 10)] || im.selection[convert2NativeChatId(dump.Id)] || im.selection[strings.ToLower(dump.Name)]
+}
 
-	for {
-		t, err := dec.Token()
-		if err == io.EOF {
-			break
+// importChat imports the messages of a chat in batches, messages up to the checkpoint of a previous
+// import of the same file are skipped since exports are ordered by message id
+func (im *importer) importChat(dump *entity.Dump, records func(record func(msg *entity.Message) error) error) error {
+	timeNow := time.Now()
+	checkpoint := int64(0)
+	im.chatPeers = make(map[int64]bool)
+	if !im.dryRun {
+		if err := im.db.UpsertChat(dump.Id, dump.Name, true); err != nil {
+			return err
 		}
+		var err error
+		checkpoint, err = im.db.GetImportCheckpoint(dump.Id, im.source)
 		if err != nil {
-			log.Fatalln(err)
+			return err
 		}
-
-		if count == 2 {
-			dump.Name = t.(string)
-		} else if count == 4 {
-			dump.Type = t.(string)
-		} else if count == 6 {
-			chatId, err := strconv.ParseInt(fmt.Sprintf("-100%d", int64(t.(float64))), 10, 64)
+		if checkpoint > 0 {
+			log.Printf("resuming chat %d %q after message %d", dump.Id, dump.Name, checkpoint)
+			// the members seen before the checkpoint resolve the names of later service messages
+			members, err := im.db.GetChatMembers(dump.Id)
 			if err != nil {
-				log.Fatalln(err)
+				return err
 			}
-			dump.Id = chatId
-		} else if count >= 8 { // 8
-			timeNow := time.Now().Unix()
+			for _, member := range members {
+				im.chatPeers[member.ID] = true
+				if member.FullName != "" {
+					im.peerNames[member.FullName] = member.ID
+				}
+			}
+		}
+	}
 
-			// _, err := db.Upser
-			err = db.UpsertChat(dump.Id, dump.Name, true)
+	im.totals = chatTotals{}
+	totals := &im.totals
+	lastMsgId, pending := checkpoint, 0
+	err := records(func(msg *entity.Message) error {
+		im.processed++
+		im.progress(dump)
+		if msg.Id <= checkpoint {
+			totals.resumed++
+			return nil
+		}
+
+		if im.batch == nil && !im.dryRun {
+			batch, err := im.db.BeginImportBatch()
 			if err != nil {
-				log.Fatalln(err)
+				return err
 			}
+			im.batch = batch
+		}
+		imported, err := im.importMessage(dump, msg)
+		if err != nil && err != errInvalidRecord {
+			return err
+		}
+		switch {
+		case imported:
+			totals.imported++
+		case err == errInvalidRecord:
+			totals.invalid++
+		default:
+			totals.skipped++
+		}
+		lastMsgId, pending = msg.Id, pending+1
+		if pending >= importBatchSize {
+			pending = 0
+			return im.commit(dump.Id, lastMsgId)
+		}
+		return nil
+	})
+	if err != nil {
+		if im.batch != nil {
+			im.batch.Rollback()
+			im.batch = nil
+		}
+		return err
+	}
+	if err := im.commit(dump.Id, lastMsgId); err != nil {
+		return err
+	}
 
-			cachedPeer := make(map[int64]struct{})
-			var rwMutex sync.RWMutex
+	im.chats++
+	im.messages += totals.imported
+	imported := "imported"
+	if im.dryRun {
+		imported = "to import"
+	}
+	log.Printf("chat %d %q: %d messages %s, %d skipped, %d invalid, %d already imported in %s", dump.Id, dump.Name, totals.imported, imported, totals.skipped, totals.invalid, totals.resumed, time.Since(timeNow).Round(time.Second))
+	return nil
+}
 
-			messageCount := 0
-			for dec.More() {
-				var msg entity.Message
-				if err := dec.Decode(&msg); err != nil {
-					log.Fatalln(err)
-				}
-				if msg.Type == "message" && msg.FullText != "" {
-					fromId := int64(0)
-					switch {
-					case strings.HasPrefix(msg.FromId, "channel"):
-						fromId, err = strconv.ParseInt(fmt.Sprintf("-100%s", strings.TrimPrefix(msg.FromId, "channel")), 10, 64)
-						if err != nil {
-							log.Fatalln(err)
-						}
-					case strings.HasPrefix(msg.FromId, "user"):
-						fromId, err = strconv.ParseInt(strings.TrimPrefix(msg.FromId, "user"), 10, 64)
-						if err != nil {
-							log.Fatalln(err)
-						}
-					default:
-						log.Fatalf("unknown from_id: %s", msg.FromId)
-					}
-
-					rwMutex.RLock()
-					_, ok := cachedPeer[fromId]
-					rwMutex.RUnlock()
-					if !ok {
-						rwMutex.Lock()
-						cachedPeer[fromId] = struct{}{}
-						rwMutex.Unlock()
-						if err := db.UpsertPeer(fromId, msg.From, ""); err != nil {
-							log.Fatalln(err)
-						}
-					}
-
-					msgId := msg.Id
-					fullText := msg.FullText
-					timestamp, err := strconv.ParseInt(msg.DateUnixTime, 10, 64)
-					if err != nil {
-						log.Fatalln(err)
-					}
-					if err = db.UpsertMessage(dump.Id, fromId, msgId, fullText, timestamp); err != nil {
-						log.Fatalln(err)
-					}
-
-					messageCount++
-					if messageCount%10000 == 0 {
-						log.Printf("imported %d messages", messageCount)
-					}
-				}
+// commit commits the open batch with the checkpoint of a chat
+func (im *importer) commit(chatId int64, lastMsgId int64) error {
+	if im.batch == nil {
+		return nil
+	}
+	batch := im.batch
+	im.batch = nil
+	return batch.Commit(chatId, im.source, lastMsgId)
+}
+
+// progress logs the read position in the import files with the record rate and the estimated time left
+func (im *importer) progress(dump *entity.Dump) {
+	if time.Since(im.lastProgress) < importProgressInterval {
+		return
+	}
+	im.lastProgress = time.Now()
+	elapsed := time.Since(im.started)
+	offset := im.offset
+	if offset <= 0 || im.size <= 0 {
+		return
+	}
+	rate := float64(im.processed) / elapsed.Seconds()
+	eta := time.Duration(float64(elapsed) * float64(im.size-offset) / float64(offset))
+	log.Printf("chat %d %q: %.1f%% of import, %d records, %.0f records/s, ETA %s", dump.Id, dump.Name, float64(offset)*100/float64(im.size), im.processed, rate, eta.Round(time.Second))
+}
+
+// importMessage stores an exported message, false is returned for records which are not indexed
+// and invalid records are logged and skipped
+func (im *importer) importMessage(dump *entity.Dump, msg *entity.Message) (bool, error) {
+	if msg.Type == "service" {
+		return false, im.importService(dump, msg)
+	}
+	media := mediaFromExport(msg)
+	text := exportText(msg)
+	if msg.Type != "message" || (text == "" && media == nil) {
+		return false, nil
+	}
+
+	fromId, err := exportPeerId(msg.FromId)
+	if err != nil {
+		im.skip(dump, fmt.Sprintf("message %d", msg.Id), err.Error())
+		return false, errInvalidRecord
+	}
+	timestamp, err := exportTimestamp(msg)
+	if err != nil {
+		im.skip(dump, fmt.Sprintf("message %d", msg.Id), err.Error())
+		return false, errInvalidRecord
+	}
+	if im.dryRun {
+		return true, nil
+	}
+
+	if _, ok := im.cachedPeer[fromId]; !ok {
+		im.cachedPeer[fromId] = struct{}{}
+		if err := im.batch.UpsertPeer(fromId, msg.From, ""); err != nil {
+			return false, err
+		}
+	}
+	if err := im.addMember(dump, fromId, msg.From); err != nil {
+		return false, err
+	}
+
+	if err := im.batch.UpsertMessage(dump.Id, fromId, msg.Id, text, formattingFromExport(msg), timestamp, relationsFromExport(msg), media, entitiesFromExport(msg)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// importService handles the service messages of forum topics and members
+func (im *importer) importService(dump *entity.Dump, msg *entity.Message) error {
+	if im.dryRun {
+		return nil
+	}
+	// the actor of a member service message is often the only member, e.g. when joining or leaving
+	if actorId, err := exportPeerId(msg.ActorId); err == nil && actorId > 0 && msg.Actor != "" {
+		im.peerNames[msg.Actor] = actorId
+	}
+	switch msg.Action {
+	case "topic_created":
+		// the message creating a forum topic starts its thread
+		if msg.Title != "" {
+			return im.batch.UpsertForumTopic(dump.Id, msg.Id, msg.Title)
+		}
+	case "join_group_by_link", "join_group_by_request":
+		actorId, err := exportPeerId(msg.ActorId)
+		if err != nil {
+			return nil
+		}
+		return im.addMember(dump, actorId, msg.Actor)
+	case "invite_members", "remove_members":
+		for _, name := range msg.Members {
+			peerId, ok := im.peerNames[name]
+			if !ok {
+				continue
+			}
+			var err error
+			if msg.Action == "invite_members" {
+				err = im.addMember(dump, peerId, name)
+			} else {
+				err = im.removeMember(dump, peerId)
+			}
+			if err != nil {
+				return err
 			}
+		}
+	}
+	return nil
+}
+
+// addMember adds a user to the members of a chat unless members are not imported
+func (im *importer) addMember(dump *entity.Dump, peerId int64, name string) error {
+	// channels posting in groups are no members, neither are the placeholders of html exports
+	if peerId <= 0 {
+		return nil
+	}
+	if name != "" {
+		im.peerNames[name] = peerId
+	}
+	if !im.members || im.chatPeers[peerId] {
+		return nil
+	}
+	im.chatPeers[peerId] = true
+	if _, ok := im.cachedPeer[peerId]; !ok {
+		im.cachedPeer[peerId] = struct{}{}
+		if err := im.batch.UpsertPeer(peerId, name, ""); err != nil {
+			return err
+		}
+	}
+	return im.batch.InsertChatPeer(dump.Id, peerId)
+}
+
+func (im *importer) removeMember(dump *entity.Dump, peerId int64) error {
+	if !im.members {
+		return nil
+	}
+	delete(im.chatPeers, peerId)
+	return im.batch.DeleteChatPeer(dump.Id, peerId)
+}
+
+// errInvalidRecord is returned by importMessage for records which are skipped because they are invalid
+var errInvalidRecord = errors.New("invalid record")
+
+// skip logs an invalid record, which is collected into the report of a dry run instead
+func (im *importer) skip(dump *entity.Dump, record, reason string) {
+	if !im.dryRun {
+		log.Printf("chat %d %q: skipping %s: %s", dump.Id, dump.Name, record, reason)
+		return
+	}
+	// the reason is grouped without the values which differ per record
+	key, _, _ := strings.Cut(reason, ": ")
+	im.report.invalid[key]++
+	if len(im.report.examples[key]) < importReportExamples {
+		im.report.examples[key] = append(im.report.examples[key], fmt.Sprintf("chat %d %s: %s", dump.Id, record, reason))
+	}
+}
+
+// inspect counts the type and unknown fields of a record for the report of a dry run, raw is nil
+// for records which are not read from json
+func (im *importer) inspect(msg *entity.Message, raw json.RawMessage) {
+	recordType := msg.Type
+	if msg.Action != "" {
+		recordType += " " + msg.Action
+	}
+	im.report.types[recordType]++
+	if raw == nil {
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return
+	}
+	for field := range fields {
+		if !exportFields[field] {
+			im.report.unknownFields[field]++
+		}
+	}
+}
+
+// exportText returns the text of an exported message, older exports only have the text field
+func exportText(msg *entity.Message) string {
+	if msg.FullText != "" {
+		return string(msg.FullText)
+	}
+	return string(msg.Text)
+}
+
+// exportPeerId converts the from_id of an exported message to a peer id
+func exportPeerId(fromId string) (int64, error) {
+	var id string
+	var ok bool
+	if id, ok = strings.CutPrefix(fromId, "user"); ok {
+		return strconv.ParseInt(id, 10, 64)
+	}
+	if id, ok = strings.CutPrefix(fromId, "channel"); ok {
+		peerId, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return convert2BotChatId(peerId), nil
+	}
+	if id, ok = strings.CutPrefix(fromId, "chat"); ok {
+		peerId, err := strconv.ParseInt(id, 10, 64)
+		return -peerId, err
+	}
+	if fromId == "" {
+		return 0, errors.New("missing from_id")
+	}
+	return 0, fmt.Errorf("unknown from_id: %s", fromId)
+}
+
+// exportTimestamp returns the unix time of an exported message, the date without time zone of exports
+// without date_unixtime is in the local time of the exporting machine, which is assumed to be ours
+func exportTimestamp(msg *entity.Message) (int64, error) {
+	if msg.DateUnixTime != "" {
+		timestamp, err := strconv.ParseInt(msg.DateUnixTime, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid date_unixtime: %s", msg.DateUnixTime)
+		}
+		return timestamp, nil
+	}
+	if msg.Date == "" {
+		return 0, errors.New("missing date_unixtime")
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", msg.Date, time.Local)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date: %s", msg.Date)
+	}
+	return t.Unix(), nil
+}
+
+// readObject reads a json object and calls field for every key, field has to read the value
+func readObject(dec *json.Decoder, field func(key string) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("unexpected %v at offset %d", t, dec.InputOffset())
+		}
+		if err := field(key); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// readArray reads a json array and calls element for every element, element has to read it
+func readArray(dec *json.Decoder, element func() error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		if err := element(); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
 
-			elapsedSeconds := time.Now().Unix() - timeNow
-			log.Printf("chat %d imported in %d seconds", dump.Id, elapsedSeconds)
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("expected %v but got %v at offset %d", delim, t, dec.InputOffset())
+	}
+	return nil
+}
 
-			break
+// skipValue reads the next value without decoding it so large skipped chats are not held in memory
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth <= 0 {
+			return nil
 		}
-		count++
 	}
 }
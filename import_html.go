@@ -0,0 +1,474 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JasonKhew96/telegram-search-bot-go/entity"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlMediaClasses maps the classes of the media blocks of html exports to media types of json exports
+var htmlMediaClasses = map[string]string{
+	"video_file_wrap":     "video_file",
+	"media_video":         "video_file",
+	"animated_wrap":       "animation",
+	"sticker_wrap":        "sticker",
+	"media_voice_message": "voice_message",
+	"media_audio_file":    "audio_file",
+}
+
+// htmlFormattingTags maps the formatting tags of html exports to text entity types of json exports
+var htmlFormattingTags = map[atom.Atom]string{
+	atom.Strong: "bold",
+	atom.B:      "bold",
+	atom.Em:     "italic",
+	atom.I:      "italic",
+	atom.U:      "underline",
+	atom.S:      "strikethrough",
+	atom.Code:   "code",
+	atom.Pre:    "pre",
+}
+
+// importHTML imports an html export, which is split into messages.html, messages2.html, ... and has
+// neither the id of the chat nor the ids of the authors, so the chat is the one selected by -chats
+func (im *importer) importHTML(importFile string) error {
+	// the pages are read from the directory or from messages.html on, any other page is imported alone
+	files := []string{importFile}
+	dir := filepath.Dir(importFile)
+	if !strings.EqualFold(filepath.Ext(importFile), ".html") {
+		dir = importFile
+		files = []string{filepath.Join(dir, "messages.html")}
+	}
+	if filepath.Base(files[0]) == "messages.html" {
+		for i := 2; ; i++ {
+			file := filepath.Join(dir, fmt.Sprintf("messages%d.html", i))
+			if _, err := os.Stat(file); err != nil {
+				break
+			}
+			files = append(files, file)
+		}
+		// the checkpoint is shared by the directory and its messages.html
+		var err error
+		if im.source, err = filepath.Abs(dir); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		stat, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		im.size += stat.Size()
+	}
+
+	chatId, err := im.htmlChatId()
+	if err != nil {
+		return err
+	}
+	doc, err := parseHTMLFile(files[0])
+	if err != nil {
+		return err
+	}
+	dump := &entity.Dump{Id: chatId, Name: htmlChatName(doc)}
+	if err := im.loadPlaceholders(); err != nil {
+		return err
+	}
+	log.Printf("importing %d html files of chat %d %q", len(files), dump.Id, dump.Name)
+
+	return im.importChat(dump, func(record func(msg *entity.Message) error) error {
+		var done int64
+		var lastFrom string
+		for i, file := range files {
+			if i > 0 {
+				if doc, err = parseHTMLFile(file); err != nil {
+					return err
+				}
+			}
+			stat, err := os.Stat(file)
+			if err != nil {
+				return err
+			}
+			nodes := findHTMLNodes(doc, func(n *html.Node) bool {
+				return n.DataAtom == atom.Div && hasHTMLClass(n, "message")
+			})
+			for j, n := range nodes {
+				// the position in the file is estimated from the position in the parsed messages
+				im.offset = done + stat.Size()*int64(j+1)/int64(len(nodes))
+				msg, ok, err := messageFromHTML(n, lastFrom)
+				if err != nil {
+					im.processed++
+					im.totals.invalid++
+					im.skip(dump, fmt.Sprintf("%s %s", filepath.Base(file), htmlAttr(n, "id")), err.Error())
+					continue
+				}
+				if !ok {
+					continue
+				}
+				if msg.Type == "message" {
+					lastFrom = msg.From
+					if msg.From != "" {
+						peerId, err := im.htmlPeerId(dump, msg.From)
+						if err != nil {
+							return err
+						}
+						msg.FromId = "user" + strconv.FormatInt(peerId, 10)
+					}
+				}
+				if im.dryRun {
+					im.inspect(msg, nil)
+				}
+				if err := record(msg); err != nil {
+					return err
+				}
+			}
+			done += stat.Size()
+		}
+		return nil
+	})
+}
+
+// htmlChatId returns the chat selected by -chats, which is either a bot chat id or the id in
+// t.me/c links of a supergroup or channel
+func (im *importer) htmlChatId() (int64, error) {
+	var chatId int64
+	for chat := range im.selection {
+		id, err := strconv.ParseInt(chat, 10, 64)
+		if err != nil || chatId != 0 {
+			chatId = 0
+			break
+		}
+		chatId = id
+		if chatId > 0 {
+			chatId = convert2BotChatId(chatId)
+		}
+	}
+	if chatId == 0 {
+		return 0, errors.New("html exports have no chat id, select the chat by its id with -chats")
+	}
+	return chatId, nil
+}
+
+// loadPlaceholders reads the placeholder peers of previous html imports, so authors keep their
+// placeholder when a chat is imported again
+func (im *importer) loadPlaceholders() error {
+	im.placeholders = make(map[string]int64)
+	im.lastPlaceholder = placeholderPeerIdMax + 1
+	if im.dryRun {
+		return nil
+	}
+	peers, err := im.db.GetPlaceholderPeers()
+	if err != nil {
+		return err
+	}
+	for _, peer := range peers {
+		im.placeholders[peer.FullName] = peer.ID
+		im.lastPlaceholder = min(im.lastPlaceholder, peer.ID)
+	}
+	return nil
+}
+
+// htmlPeerId maps the name of an author to the member of the chat with that name, or the only
+// peer with it, and otherwise to a placeholder peer per name
+func (im *importer) htmlPeerId(dump *entity.Dump, name string) (int64, error) {
+	if peerId, ok := im.peerNames[name]; ok {
+		return peerId, nil
+	}
+	var peerId int64
+	if !im.dryRun {
+		var err error
+		// the import uses a single connection, which is held by an open batch
+		if im.batch != nil {
+			peerId, err = im.batch.GetPeerIdByFullName(dump.Id, name)
+		} else {
+			peerId, err = im.db.GetPeerIdByFullName(dump.Id, name)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if peerId == 0 {
+		var ok bool
+		if peerId, ok = im.placeholders[name]; !ok {
+			im.lastPlaceholder--
+			peerId = im.lastPlaceholder
+			im.placeholders[name] = peerId
+		}
+	}
+	im.peerNames[name] = peerId
+	return peerId, nil
+}
+
+func parseHTMLFile(file string) (*html.Node, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return html.Parse(f)
+}
+
+func htmlChatName(doc *html.Node) string {
+	for _, header := range findHTMLNodes(doc, func(n *html.Node) bool { return hasHTMLClass(n, "page_header") }) {
+		for _, name := range findHTMLNodes(header, func(n *html.Node) bool { return hasHTMLClass(n, "text") && hasHTMLClass(n, "bold") }) {
+			return strings.TrimSpace(htmlText(name))
+		}
+	}
+	return ""
+}
+
+// messageFromHTML converts a message div of an html export, false is returned for the date
+// separators, which have negative ids. Joined messages are sent by the author of the previous one
+func messageFromHTML(n *html.Node, lastFrom string) (*entity.Message, bool, error) {
+	id, ok := strings.CutPrefix(htmlAttr(n, "id"), "message")
+	if !ok {
+		return nil, false, errors.New("missing message id")
+	}
+	msgId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid message id: %s", id)
+	}
+	if msgId <= 0 {
+		return nil, false, nil
+	}
+	msg := &entity.Message{Id: msgId, Type: "message"}
+	if hasHTMLClass(n, "service") {
+		msg.Type = "service"
+		return msg, true, nil
+	}
+
+	body := htmlChild(n, func(n *html.Node) bool { return hasHTMLClass(n, "body") })
+	if body == nil {
+		return nil, false, errors.New("missing message body")
+	}
+	msg.From = lastFrom
+	if from := htmlChild(body, func(n *html.Node) bool { return hasHTMLClass(n, "from_name") }); from != nil {
+		msg.From = htmlName(from)
+	}
+	for _, date := range findHTMLNodes(body, func(n *html.Node) bool { return hasHTMLClass(n, "date") && htmlAttr(n, "title") != "" }) {
+		timestamp, err := htmlTimestamp(htmlAttr(date, "title"))
+		if err != nil {
+			return nil, false, err
+		}
+		msg.DateUnixTime = strconv.FormatInt(timestamp, 10)
+		break
+	}
+	if reply := htmlChild(body, func(n *html.Node) bool { return hasHTMLClass(n, "reply_to") }); reply != nil {
+		for _, a := range findHTMLNodes(reply, func(n *html.Node) bool { return n.DataAtom == atom.A }) {
+			// replies to messages in another file are prefixed with its name
+			if _, id, ok := strings.Cut(htmlAttr(a, "href"), "go_to_message"); ok {
+				msg.ReplyToMessageId, _ = strconv.ParseInt(id, 10, 64)
+			}
+		}
+	}
+	if forwarded := htmlChild(body, func(n *html.Node) bool { return hasHTMLClass(n, "forwarded") }); forwarded != nil {
+		if from := htmlChild(forwarded, func(n *html.Node) bool { return hasHTMLClass(n, "from_name") }); from != nil {
+			msg.ForwardedFrom = htmlName(from)
+		}
+	}
+
+	for _, media := range findHTMLNodes(body, func(n *html.Node) bool {
+		return hasHTMLClass(n, "photo_wrap") || hasHTMLClass(n, "media_photo") || hasHTMLClass(n, "media_file") || htmlMediaType(n) != ""
+	}) {
+		file := htmlAttr(media, "href")
+		if file == "" {
+			// the file was not exported
+			file = "(File not included)"
+		}
+		switch {
+		case hasHTMLClass(media, "photo_wrap"), hasHTMLClass(media, "media_photo"):
+			msg.Photo = &file
+		case hasHTMLClass(media, "media_file"):
+			msg.File = &file
+			for _, title := range findHTMLNodes(media, func(n *html.Node) bool { return hasHTMLClass(n, "title") }) {
+				msg.FileName = strings.TrimSpace(htmlText(title))
+				break
+			}
+		default:
+			mediaType := htmlMediaType(media)
+			msg.File, msg.MediaType = &file, &mediaType
+		}
+		break
+	}
+
+	for _, text := range findHTMLNodes(body, func(n *html.Node) bool { return n.DataAtom == atom.Div && hasHTMLClass(n, "text") }) {
+		msg.TextEntities = textEntitiesFromHTML(text)
+		break
+	}
+	var sb strings.Builder
+	for _, e := range msg.TextEntities {
+		sb.WriteString(e.Text)
+	}
+	msg.FullText = entity.Text(sb.String())
+	return msg, true, nil
+}
+
+// htmlTimestamp parses the date title of an html export, older exports have no time zone and are
+// in the local time of the exporting machine, which is assumed to be ours
+func htmlTimestamp(title string) (int64, error) {
+	t, err := time.Parse("02.01.2006 15:04:05 UTC-07:00", title)
+	if err != nil {
+		t, err = time.ParseInLocation("02.01.2006 15:04:05", title, time.Local)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid date: %s", title)
+	}
+	return t.Unix(), nil
+}
+
+func htmlMediaType(n *html.Node) string {
+	for class, mediaType := range htmlMediaClasses {
+		if hasHTMLClass(n, class) {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+// textEntitiesFromHTML converts the text div of an html export to the text entities of json exports,
+// nested formatting is flattened to the outermost one like in json exports
+func textEntitiesFromHTML(n *html.Node) []entity.TextEntity {
+	var entities []entity.TextEntity
+	plain := func(text string) {
+		if text == "" {
+			return
+		}
+		if last := len(entities) - 1; last >= 0 && entities[last].Type == "plain" {
+			entities[last].Text += text
+			return
+		}
+		entities = append(entities, entity.TextEntity{Type: "plain", Text: text})
+	}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.TextNode:
+				plain(c.Data)
+			case c.Type != html.ElementNode:
+			case c.DataAtom == atom.Br:
+				plain("\n")
+			case c.DataAtom == atom.A:
+				entities = append(entities, linkFromHTML(c))
+			case htmlFormattingTags[c.DataAtom] != "":
+				entities = append(entities, entity.TextEntity{Type: htmlFormattingTags[c.DataAtom], Text: htmlText(c)})
+			case c.DataAtom == atom.Span && hasHTMLClass(c, "spoiler"):
+				entities = append(entities, entity.TextEntity{Type: "spoiler", Text: htmlText(c)})
+			default:
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+
+	// the text is indented in the export
+	if len(entities) > 0 && entities[0].Type == "plain" {
+		entities[0].Text = strings.TrimLeft(entities[0].Text, " \t\r\n")
+	}
+	if last := len(entities) - 1; last >= 0 && entities[last].Type == "plain" {
+		entities[last].Text = strings.TrimRight(entities[last].Text, " \t\r\n")
+	}
+	result := entities[:0]
+	for _, e := range entities {
+		if e.Text != "" {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// linkFromHTML converts a link of an html export, which are also used for hashtags and mentions
+func linkFromHTML(n *html.Node) entity.TextEntity {
+	text, href := htmlText(n), htmlAttr(n, "href")
+	switch {
+	case strings.HasPrefix(text, "#"):
+		return entity.TextEntity{Type: "hashtag", Text: text}
+	case strings.HasPrefix(text, "@"):
+		return entity.TextEntity{Type: "mention", Text: text}
+	case href == "":
+		return entity.TextEntity{Type: "plain", Text: text}
+	case href == text || strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(href, "http://"), "https://"), "/") == strings.TrimSuffix(text, "/"):
+		return entity.TextEntity{Type: "link", Text: text}
+	}
+	return entity.TextEntity{Type: "text_link", Text: text, Href: href}
+}
+
+// htmlName returns the name in a from_name div without the date of forwards and the via bot details
+func htmlName(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && hasHTMLClass(c, "details") {
+			continue
+		}
+		sb.WriteString(htmlText(c))
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// htmlText returns the text of a node and its descendants
+func htmlText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom == atom.Br {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(htmlText(c))
+	}
+	return sb.String()
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasHTMLClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(htmlAttr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlChild returns the first element child of a node which matches
+func htmlChild(n *html.Node, match func(n *html.Node) bool) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && match(c) {
+			return c
+		}
+	}
+	return nil
+}
+
+// findHTMLNodes returns the descendant elements of a node which match in document order, the
+// descendants of matching elements are not searched
+func findHTMLNodes(n *html.Node, match func(n *html.Node) bool) []*html.Node {
+	var nodes []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		if match(c) {
+			nodes = append(nodes, c)
+			continue
+		}
+		nodes = append(nodes, findHTMLNodes(c, match)...)
+	}
+	return nodes
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/JasonKhew96/telegram-search-bot-go/entity"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// parseHTMLFragment returns the first div of a class in the html
+func parseHTMLFragment(t *testing.T, text, class string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := findHTMLNodes(doc, func(n *html.Node) bool { return n.DataAtom == atom.Div && hasHTMLClass(n, class) })
+	if len(nodes) <= 0 {
+		t.Fatalf("no %s div in %s", class, text)
+	}
+	return nodes[0]
+}
+
+func TestMessageFromHTML(t *testing.T) {
+	file := func(name string) *string { return &name }
+	tests := []struct {
+		name     string
+		html     string
+		lastFrom string
+		want     *entity.Message
+	}{
+		{
+			name: "date separator",
+			html: `<div class="message service" id="message-1"><div class="body details">10 March 2024</div></div>`,
+		},
+		{
+			name: "service message",
+			html: `<div class="message service" id="message5"><div class="body details">Alice joined the group</div></div>`,
+			want: &entity.Message{Id: 5, Type: "service"},
+		},
+		{
+			name: "date with time zone",
+			html: `<div class="message default clearfix" id="message10">
+ <div class="pull_left userpic_wrap"><div class="userpic userpic1"><div class="initials">A</div></div></div>
+ <div class="body">
+  <div class="pull_right date details" title="10.03.2024 12:34:56 UTC+08:00">12:34</div>
+  <div class="from_name">
+Alice 
+  </div>
+  <div class="text">
+hello <strong>world</strong>
+  </div>
+ </div>
+</div>`,
+			want: &entity.Message{
+				Id: 10, Type: "message", DateUnixTime: "1710045296", From: "Alice", FullText: "hello world",
+				TextEntities: []entity.TextEntity{{Type: "plain", Text: "hello "}, {Type: "bold", Text: "world"}},
+			},
+		},
+		{
+			name: "joined message of the previous author",
+			html: `<div class="message default clearfix joined" id="message11">
+ <div class="body">
+  <div class="pull_right date details" title="10.03.2024 12:35:00 UTC+08:00">12:35</div>
+  <div class="text">again</div>
+ </div>
+</div>`,
+			lastFrom: "Alice",
+			want: &entity.Message{
+				Id: 11, Type: "message", DateUnixTime: "1710045300", From: "Alice", FullText: "again",
+				TextEntities: []entity.TextEntity{{Type: "plain", Text: "again"}},
+			},
+		},
+		{
+			name: "reply to a message in another file",
+			html: `<div class="message default clearfix joined" id="message12">
+ <div class="body">
+  <div class="pull_right date details" title="10.03.2024 12:36:00 UTC+08:00">12:36</div>
+  <div class="reply_to details">In reply to <a href="messages2.html#go_to_message10" onclick="return GoToMessage(10)">this message</a></div>
+  <div class="text">yes</div>
+ </div>
+</div>`,
+			lastFrom: "Alice",
+			want: &entity.Message{
+				Id: 12, Type: "message", DateUnixTime: "1710045360", From: "Alice", FullText: "yes", ReplyToMessageId: 10,
+				TextEntities: []entity.TextEntity{{Type: "plain", Text: "yes"}},
+			},
+		},
+		{
+			name: "forwarded photo",
+			html: `<div class="message default clearfix" id="message13">
+ <div class="body">
+  <div class="pull_right date details" title="10.03.2024 12:37:00 UTC+08:00">12:37</div>
+  <div class="from_name">Bob</div>
+  <div class="forwarded body">
+   <div class="from_name">Carol<span class="date details" title="01.01.2024 00:00:00 UTC+08:00"> 01.01.2024 00:00:00</span></div>
+   <div class="media_wrap clearfix"><a class="photo_wrap clearfix pull_left" href="photos/photo_1@10-03-2024_12-37-00.jpg"><img class="photo" src="photos/photo_1@10-03-2024_12-37-00_thumb.jpg"></a></div>
+   <div class="text">look</div>
+  </div>
+ </div>
+</div>`,
+			want: &entity.Message{
+				Id: 13, Type: "message", DateUnixTime: "1710045420", From: "Bob", ForwardedFrom: "Carol", FullText: "look",
+				Photo:        file("photos/photo_1@10-03-2024_12-37-00.jpg"),
+				TextEntities: []entity.TextEntity{{Type: "plain", Text: "look"}},
+			},
+		},
+		{
+			name: "document",
+			html: `<div class="message default clearfix" id="message14">
+ <div class="body">
+  <div class="pull_right date details" title="10.03.2024 12:38:00 UTC+08:00">12:38</div>
+  <div class="from_name">Bob</div>
+  <div class="media_wrap clearfix">
+   <a class="media clearfix pull_left block_link media_file" href="files/report.pdf">
+    <div class="fill pull_left"></div>
+    <div class="body"><div class="title bold">report.pdf</div><div class="status details">1.2 MB</div></div>
+   </a>
+  </div>
+ </div>
+</div>`,
+			want: &entity.Message{
+				Id: 14, Type: "message", DateUnixTime: "1710045480", From: "Bob",
+				File: file("files/report.pdf"), FileName: "report.pdf",
+			},
+		},
+		{
+			name: "voice message which was not exported",
+			html: `<div class="message default clearfix" id="message15">
+ <div class="body">
+  <div class="pull_right date details" title="10.03.2024 12:39:00 UTC+08:00">12:39</div>
+  <div class="from_name">Bob</div>
+  <div class="media_wrap clearfix">
+   <div class="media clearfix pull_left media_voice_message"><div class="body"><div class="title bold">Voice message</div></div></div>
+  </div>
+ </div>
+</div>`,
+			want: &entity.Message{
+				Id: 15, Type: "message", DateUnixTime: "1710045540", From: "Bob",
+				File: file("(File not included)"), MediaType: file("voice_message"),
+			},
+		},
+	}
+	for _, test := range tests {
+		msg, ok, err := messageFromHTML(parseHTMLFragment(t, test.html, "message"), test.lastFrom)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if ok != (test.want != nil) {
+			t.Errorf("%s: ok = %v", test.name, ok)
+			continue
+		}
+		if !reflect.DeepEqual(msg, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, msg, test.want)
+		}
+	}
+}
+
+func TestMessageFromHTMLErrors(t *testing.T) {
+	tests := []struct {
+		html string
+		err  string
+	}{
+		{`<div class="message default"><div class="body"></div></div>`, "missing message id"},
+		{`<div class="message default" id="messageabc"><div class="body"></div></div>`, "invalid message id: abc"},
+		{`<div class="message default" id="message1"></div>`, "missing message body"},
+		{`<div class="message default" id="message1"><div class="body"><div class="date details" title="yesterday">12:34</div></div></div>`, "invalid date: yesterday"},
+	}
+	for _, test := range tests {
+		_, _, err := messageFromHTML(parseHTMLFragment(t, test.html, "message"), "")
+		if err == nil || err.Error() != test.err {
+			t.Errorf("messageFromHTML(%s) error = %v, want %q", test.html, err, test.err)
+		}
+	}
+}
+
+func TestTextEntitiesFromHTML(t *testing.T) {
+	tests := []struct {
+		html string
+		want []entity.TextEntity
+	}{
+		{"\n  hello\n  ", []entity.TextEntity{{Type: "plain", Text: "hello"}}},
+		{"first<br>second", []entity.TextEntity{{Type: "plain", Text: "first\nsecond"}}},
+		{"a <span>plain</span> span", []entity.TextEntity{{Type: "plain", Text: "a plain span"}}},
+		{
+			"<strong><em>both</em></strong> <em>italic</em> <u>under</u> <s>struck</s>",
+			[]entity.TextEntity{
+				{Type: "bold", Text: "both"}, {Type: "plain", Text: " "}, {Type: "italic", Text: "italic"}, {Type: "plain", Text: " "},
+				{Type: "underline", Text: "under"}, {Type: "plain", Text: " "}, {Type: "strikethrough", Text: "struck"},
+			},
+		},
+		{
+			"run <code>go test</code><pre>x := 1\ny := 2</pre>",
+			[]entity.TextEntity{{Type: "plain", Text: "run "}, {Type: "code", Text: "go test"}, {Type: "pre", Text: "x := 1\ny := 2"}},
+		},
+		{
+			`see <a href="https://go.dev/">https://go.dev/</a> and <a href="https://go.dev/doc">the docs</a>`,
+			[]entity.TextEntity{
+				{Type: "plain", Text: "see "}, {Type: "link", Text: "https://go.dev/"}, {Type: "plain", Text: " and "},
+				{Type: "text_link", Text: "the docs", Href: "https://go.dev/doc"},
+			},
+		},
+		{
+			`<a href="http://go.dev">go.dev</a>`,
+			[]entity.TextEntity{{Type: "link", Text: "go.dev"}},
+		},
+		{
+			`<a href="" onclick="return ShowHashtag(&quot;golang&quot;)">#golang</a> <a href="https://t.me/alice">@alice</a>`,
+			[]entity.TextEntity{{Type: "hashtag", Text: "#golang"}, {Type: "plain", Text: " "}, {Type: "mention", Text: "@alice"}},
+		},
+		{
+			`<span class="spoiler hidden" onclick="ShowSpoiler(this)"><span aria-hidden="true">secret</span></span>`,
+			[]entity.TextEntity{{Type: "spoiler", Text: "secret"}},
+		},
+		{"", nil},
+	}
+	for _, test := range tests {
+		got := textEntitiesFromHTML(parseHTMLFragment(t, `<div class="text">`+test.html+`</div>`, "text"))
+		if len(got) <= 0 && len(test.want) <= 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("textEntitiesFromHTML(%q) = %+v, want %+v", test.html, got, test.want)
+		}
+	}
+}
+
+// htmlExport is an html export page with messages of Alice, who is a known peer, and of Bob and
+// Carol, who are not
+const htmlExport = `<!DOCTYPE html><html><body><div class="page_wrap">
+<div class="page_header"><div class="content"><div class="text bold">My Group</div></div></div>
+<div class="page_body chat_page"><div class="history">
+<div class="message default clearfix" id="message1"><div class="body"><div class="pull_right date details" title="10.03.2024 12:00:00 UTC+08:00">12:00</div><div class="from_name">Alice</div><div class="text">hello</div></div></div>
+<div class="message default clearfix" id="message2"><div class="body"><div class="pull_right date details" title="10.03.2024 12:01:00 UTC+08:00">12:01</div><div class="from_name">Bob</div><div class="text">hi</div></div></div>
+<div class="message default clearfix joined" id="message3"><div class="body"><div class="pull_right date details" title="10.03.2024 12:02:00 UTC+08:00">12:02</div><div class="text">again</div></div></div>
+<div class="message default clearfix" id="message4"><div class="body"><div class="pull_right date details" title="10.03.2024 12:03:00 UTC+08:00">12:03</div><div class="from_name">Carol</div><div class="text">hey</div></div></div>
+</div></div></div></body></html>`
+
+func TestImportHTMLPlaceholders(t *testing.T) {
+	d := newTestDatabase(t)
+	if err := d.UpsertPeer(114514, "Alice", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	importDir := func() map[int64]int64 {
+		t.Helper()
+		// a new directory has no checkpoint, so the chat is imported again
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "messages.html"), []byte(htmlExport), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		im := newTestImporter(d)
+		im.selection["1234567890"] = true
+		if err := im.importHTML(dir); err != nil {
+			t.Fatal(err)
+		}
+		fromIds := make(map[int64]int64)
+		for msgId := int64(1); msgId <= 4; msgId++ {
+			msg, err := d.GetMessage(-1001234567890, msgId)
+			if err != nil {
+				t.Fatalf("message %d: %v", msgId, err)
+			}
+			fromIds[msgId] = msg.FromID
+		}
+		return fromIds
+	}
+
+	fromIds := importDir()
+	if fromIds[1] != 114514 {
+		t.Errorf("message of Alice is from %d, want 114514", fromIds[1])
+	}
+	bob, carol := fromIds[2], fromIds[4]
+	if bob > placeholderPeerIdMax || carol > placeholderPeerIdMax || bob == carol {
+		t.Errorf("placeholders of Bob and Carol are %d and %d", bob, carol)
+	}
+	if fromIds[3] != bob {
+		t.Errorf("joined message is from %d, want %d", fromIds[3], bob)
+	}
+	// placeholders are no peers for name lookups
+	if peerId, err := d.GetPeerIdByFullName(-1001234567890, "Bob"); err != nil || peerId != 0 {
+		t.Errorf("GetPeerIdByFullName(Bob) = %d, %v", peerId, err)
+	}
+
+	// another import keeps the placeholders
+	if again := importDir(); !reflect.DeepEqual(again, fromIds) {
+		t.Errorf("second import has authors %v, want %v", again, fromIds)
+	}
+}
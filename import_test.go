@@ -0,0 +1,184 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportChatId(t *testing.T) {
+	tests := map[int64]int64{
+		123456789:  -100123456789,
+		1234567890: -1001234567890,
+		114514:     -100114514,
+	}
+	for id, want := range tests {
+		if got := exportChatId(id); got != want {
+			t.Errorf("exportChatId(%d) = %d, want %d", id, got, want)
+		}
+	}
+}
+
+// exportMessage returns an exported text message of user 114514
+func exportMessage(id int64, text string) string {
+	return fmt.Sprintf(`{"id": %d, "type": "message", "date_unixtime": "%d", "from": "Alice", "from_id": "user114514", "text": %q}`, id, 1710000000+id, text)
+}
+
+// exportChat returns the export of a single chat with the messages
+func exportChat(chatType string, id int64, messages ...string) string {
+	return fmt.Sprintf(`{"name": "Chat %d", "type": %q, "id": %d, "messages": [`, id, chatType, id) + strings.Join(messages, ", ") + `]}`
+}
+
+func newTestImporter(d *Database) *importer {
+	return &importer{
+		db:           d,
+		source:       "/export/result.json",
+		selection:    make(map[string]bool),
+		cachedPeer:   make(map[int64]struct{}),
+		peerNames:    make(map[string]int64),
+		started:      time.Now(),
+		lastProgress: time.Now(),
+	}
+}
+
+func TestImportChatTypes(t *testing.T) {
+	d := newTestDatabase(t)
+
+	// the chat of a single chat export is imported whatever its type
+	im := newTestImporter(d)
+	if err := im.importFile(json.NewDecoder(strings.NewReader(exportChat("personal_chat", 114514, exportMessage(1, "hello"))))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetMessage(-100114514, 1); err != nil {
+		t.Errorf("personal chat of a single chat export: %v", err)
+	}
+
+	// account exports only import groups and channels unless chats are selected
+	account := `{"about": "", "chats": {"about": "", "list": [` +
+		exportChat("personal_chat", 1919810, exportMessage(1, "hi")) + ", " +
+		exportChat("private_group", 123456789, exportMessage(1, "hey")) + `]}}`
+	im = newTestImporter(d)
+	im.source = "/export/account.json"
+	if err := im.importFile(json.NewDecoder(strings.NewReader(account))); err != nil {
+		t.Fatal(err)
+	}
+	if im.chats != 1 {
+		t.Errorf("account export imported %d chats, want 1", im.chats)
+	}
+	if _, err := d.GetMessage(-100123456789, 1); err != nil {
+		t.Errorf("basic group of an account export: %v", err)
+	}
+	if _, err := d.GetMessage(-1001919810, 1); err != sql.ErrNoRows {
+		t.Errorf("personal chat of an account export: got %v, want %v", err, sql.ErrNoRows)
+	}
+}
+
+func TestImportResume(t *testing.T) {
+	d := newTestDatabase(t)
+
+	// the first run stopped after the second message
+	im := newTestImporter(d)
+	if err := im.importFile(json.NewDecoder(strings.NewReader(exportChat("private_supergroup", 1234567890, exportMessage(1, "hello"), exportMessage(2, "world"))))); err != nil {
+		t.Fatal(err)
+	}
+	if im.messages != 2 {
+		t.Fatalf("first run imported %d messages, want 2", im.messages)
+	}
+
+	// messages up to the checkpoint are skipped, the changed text of the first one is not read
+	im = newTestImporter(d)
+	if err := im.importFile(json.NewDecoder(strings.NewReader(exportChat("private_supergroup", 1234567890, exportMessage(1, "edited"), exportMessage(2, "world"), exportMessage(3, "again"))))); err != nil {
+		t.Fatal(err)
+	}
+	if im.messages != 1 {
+		t.Errorf("rerun imported %d messages, want 1", im.messages)
+	}
+	for msgId, want := range map[int64]string{1: "hello", 2: "world", 3: "again"} {
+		msg, err := d.GetMessage(-1001234567890, msgId)
+		if err != nil {
+			t.Fatalf("message %d: %v", msgId, err)
+		}
+		if msg.Text != want {
+			t.Errorf("message %d text = %q, want %q", msgId, msg.Text, want)
+		}
+	}
+	checkpoint, err := d.GetImportCheckpoint(-1001234567890, "/export/result.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint != 3 {
+		t.Errorf("checkpoint = %d, want 3", checkpoint)
+	}
+}
+
+func TestImportResumeMembers(t *testing.T) {
+	d := newTestDatabase(t)
+	join := `{"id": 1, "type": "service", "date_unixtime": "1710000001", "action": "join_group_by_link", "actor": "Bob", "actor_id": "user1919810"}`
+	remove := `{"id": 2, "type": "service", "date_unixtime": "1710000002", "action": "remove_members", "actor": "Alice", "actor_id": "user114514", "members": ["Bob"]}`
+
+	im := newTestImporter(d)
+	im.members = true
+	if err := im.importFile(json.NewDecoder(strings.NewReader(exportChat("private_supergroup", 1234567890, join)))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetChatPeers(-1001234567890, 1919810); err != nil {
+		t.Fatalf("member after the first run: %v", err)
+	}
+
+	// the name of the member who joined before the checkpoint is known after resuming
+	im = newTestImporter(d)
+	im.members = true
+	if err := im.importFile(json.NewDecoder(strings.NewReader(exportChat("private_supergroup", 1234567890, join, remove)))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetChatPeers(-1001234567890, 1919810); err != sql.ErrNoRows {
+		t.Errorf("removed member after resuming: got %v, want %v", err, sql.ErrNoRows)
+	}
+}
+
+func TestImportKeepsUsername(t *testing.T) {
+	d := newTestDatabase(t)
+	if err := d.UpsertPeer(114514, "Alice", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	im := newTestImporter(d)
+	if err := im.importFile(json.NewDecoder(strings.NewReader(exportChat("private_supergroup", 1234567890, exportMessage(1, "hello"))))); err != nil {
+		t.Fatal(err)
+	}
+	peer, err := d.GetPeer(114514)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peer.Username != "alice" {
+		t.Errorf("username after import = %q, want %q", peer.Username, "alice")
+	}
+}
+
+func TestExportPeerId(t *testing.T) {
+	tests := []struct {
+		fromId string
+		want   int64
+	}{
+		{"user114514", 114514},
+		{"channel1234567890", -1001234567890},
+		{"chat123456789", -123456789},
+	}
+	for _, test := range tests {
+		got, err := exportPeerId(test.fromId)
+		if err != nil {
+			t.Errorf("exportPeerId(%q): %v", test.fromId, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("exportPeerId(%q) = %d, want %d", test.fromId, got, test.want)
+		}
+	}
+	for _, fromId := range []string{"", "group123", "user", "userabc"} {
+		if got, err := exportPeerId(fromId); err == nil {
+			t.Errorf("exportPeerId(%q) = %d, want an error", fromId, got)
+		}
+	}
+}
@@ -5,15 +5,19 @@ import (
 )
 
 func main() {
-	importedFile := flag.String("import", "", "import tdesktop exported json file")
+	importedFile := flag.String("import", "", "import tdesktop exported json file, or html export directory with -chats set to its chat id")
+	importDryRun := flag.Bool("dry-run", false, "parse the import file and report what would be imported without writing the database")
+	importMembers := flag.Bool("import-members", true, "add the authors and members seen in the import file to the chat members")
+	importChats := flag.String("chats", "", "comma separated ids or names of the chats to import, all groups and channels of account exports by default")
 	configFile := flag.String("config", "config.yaml", "config file")
 	databaseFile := flag.String("database", "data.db", "database file")
+	dictionaryFile := flag.String("dictionary", "", "word list used to segment chinese text, one word per line")
 	flag.Parse()
 
 	if *importedFile != "" {
-		importData(*databaseFile, *importedFile)
+		importData(*databaseFile, *importedFile, *dictionaryFile, *importChats, *importDryRun, *importMembers)
 		return
 	}
 
-	StartBot(*databaseFile, *configFile)
+	StartBot(*databaseFile, *configFile, *dictionaryFile)
 }
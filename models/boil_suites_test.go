@@ -14,7 +14,12 @@ import "testing"
 func TestParent(t *testing.T) {
 	t.Run("Chats", testChats)
 	t.Run("ChatPeers", testChatPeers)
+	t.Run("ForumTopics", testForumTopics)
+	t.Run("ImportCheckpoints", testImportCheckpoints)
+	t.Run("Media", testMedia)
 	t.Run("Messages", testMessages)
+	t.Run("MessageEntities", testMessageEntities)
+	t.Run("MessageRevisions", testMessageRevisions)
 	t.Run("Peers", testPeers)
 }
 
@@ -33,70 +38,120 @@ func TestSliceSoftDeleteAll(t *testing.T) {
 func TestDelete(t *testing.T) {
 	t.Run("Chats", testChatsDelete)
 	t.Run("ChatPeers", testChatPeersDelete)
+	t.Run("ForumTopics", testForumTopicsDelete)
+	t.Run("ImportCheckpoints", testImportCheckpointsDelete)
+	t.Run("Media", testMediaDelete)
 	t.Run("Messages", testMessagesDelete)
+	t.Run("MessageEntities", testMessageEntitiesDelete)
+	t.Run("MessageRevisions", testMessageRevisionsDelete)
 	t.Run("Peers", testPeersDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
 	t.Run("Chats", testChatsQueryDeleteAll)
 	t.Run("ChatPeers", testChatPeersQueryDeleteAll)
+	t.Run("ForumTopics", testForumTopicsQueryDeleteAll)
+	t.Run("ImportCheckpoints", testImportCheckpointsQueryDeleteAll)
+	t.Run("Media", testMediaQueryDeleteAll)
 	t.Run("Messages", testMessagesQueryDeleteAll)
+	t.Run("MessageEntities", testMessageEntitiesQueryDeleteAll)
+	t.Run("MessageRevisions", testMessageRevisionsQueryDeleteAll)
 	t.Run("Peers", testPeersQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
 	t.Run("Chats", testChatsSliceDeleteAll)
 	t.Run("ChatPeers", testChatPeersSliceDeleteAll)
+	t.Run("ForumTopics", testForumTopicsSliceDeleteAll)
+	t.Run("ImportCheckpoints", testImportCheckpointsSliceDeleteAll)
+	t.Run("Media", testMediaSliceDeleteAll)
 	t.Run("Messages", testMessagesSliceDeleteAll)
+	t.Run("MessageEntities", testMessageEntitiesSliceDeleteAll)
+	t.Run("MessageRevisions", testMessageRevisionsSliceDeleteAll)
 	t.Run("Peers", testPeersSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
 	t.Run("Chats", testChatsExists)
 	t.Run("ChatPeers", testChatPeersExists)
+	t.Run("ForumTopics", testForumTopicsExists)
+	t.Run("ImportCheckpoints", testImportCheckpointsExists)
+	t.Run("Media", testMediaExists)
 	t.Run("Messages", testMessagesExists)
+	t.Run("MessageEntities", testMessageEntitiesExists)
+	t.Run("MessageRevisions", testMessageRevisionsExists)
 	t.Run("Peers", testPeersExists)
 }
 
 func TestFind(t *testing.T) {
 	t.Run("Chats", testChatsFind)
 	t.Run("ChatPeers", testChatPeersFind)
+	t.Run("ForumTopics", testForumTopicsFind)
+	t.Run("ImportCheckpoints", testImportCheckpointsFind)
+	t.Run("Media", testMediaFind)
 	t.Run("Messages", testMessagesFind)
+	t.Run("MessageEntities", testMessageEntitiesFind)
+	t.Run("MessageRevisions", testMessageRevisionsFind)
 	t.Run("Peers", testPeersFind)
 }
 
 func TestBind(t *testing.T) {
 	t.Run("Chats", testChatsBind)
 	t.Run("ChatPeers", testChatPeersBind)
+	t.Run("ForumTopics", testForumTopicsBind)
+	t.Run("ImportCheckpoints", testImportCheckpointsBind)
+	t.Run("Media", testMediaBind)
 	t.Run("Messages", testMessagesBind)
+	t.Run("MessageEntities", testMessageEntitiesBind)
+	t.Run("MessageRevisions", testMessageRevisionsBind)
 	t.Run("Peers", testPeersBind)
 }
 
 func TestOne(t *testing.T) {
 	t.Run("Chats", testChatsOne)
 	t.Run("ChatPeers", testChatPeersOne)
+	t.Run("ForumTopics", testForumTopicsOne)
+	t.Run("ImportCheckpoints", testImportCheckpointsOne)
+	t.Run("Media", testMediaOne)
 	t.Run("Messages", testMessagesOne)
+	t.Run("MessageEntities", testMessageEntitiesOne)
+	t.Run("MessageRevisions", testMessageRevisionsOne)
 	t.Run("Peers", testPeersOne)
 }
 
 func TestAll(t *testing.T) {
 	t.Run("Chats", testChatsAll)
 	t.Run("ChatPeers", testChatPeersAll)
+	t.Run("ForumTopics", testForumTopicsAll)
+	t.Run("ImportCheckpoints", testImportCheckpointsAll)
+	t.Run("Media", testMediaAll)
 	t.Run("Messages", testMessagesAll)
+	t.Run("MessageEntities", testMessageEntitiesAll)
+	t.Run("MessageRevisions", testMessageRevisionsAll)
 	t.Run("Peers", testPeersAll)
 }
 
 func TestCount(t *testing.T) {
 	t.Run("Chats", testChatsCount)
 	t.Run("ChatPeers", testChatPeersCount)
+	t.Run("ForumTopics", testForumTopicsCount)
+	t.Run("ImportCheckpoints", testImportCheckpointsCount)
+	t.Run("Media", testMediaCount)
 	t.Run("Messages", testMessagesCount)
+	t.Run("MessageEntities", testMessageEntitiesCount)
+	t.Run("MessageRevisions", testMessageRevisionsCount)
 	t.Run("Peers", testPeersCount)
 }
 
 func TestHooks(t *testing.T) {
 	t.Run("Chats", testChatsHooks)
 	t.Run("ChatPeers", testChatPeersHooks)
+	t.Run("ForumTopics", testForumTopicsHooks)
+	t.Run("ImportCheckpoints", testImportCheckpointsHooks)
+	t.Run("Media", testMediaHooks)
 	t.Run("Messages", testMessagesHooks)
+	t.Run("MessageEntities", testMessageEntitiesHooks)
+	t.Run("MessageRevisions", testMessageRevisionsHooks)
 	t.Run("Peers", testPeersHooks)
 }
 
@@ -105,8 +160,18 @@ func TestInsert(t *testing.T) {
 	t.Run("Chats", testChatsInsertWhitelist)
 	t.Run("ChatPeers", testChatPeersInsert)
 	t.Run("ChatPeers", testChatPeersInsertWhitelist)
+	t.Run("ForumTopics", testForumTopicsInsert)
+	t.Run("ForumTopics", testForumTopicsInsertWhitelist)
+	t.Run("ImportCheckpoints", testImportCheckpointsInsert)
+	t.Run("ImportCheckpoints", testImportCheckpointsInsertWhitelist)
+	t.Run("Media", testMediaInsert)
+	t.Run("Media", testMediaInsertWhitelist)
 	t.Run("Messages", testMessagesInsert)
 	t.Run("Messages", testMessagesInsertWhitelist)
+	t.Run("MessageEntities", testMessageEntitiesInsert)
+	t.Run("MessageEntities", testMessageEntitiesInsertWhitelist)
+	t.Run("MessageRevisions", testMessageRevisionsInsert)
+	t.Run("MessageRevisions", testMessageRevisionsInsertWhitelist)
 	t.Run("Peers", testPeersInsert)
 	t.Run("Peers", testPeersInsertWhitelist)
 }
@@ -114,34 +179,59 @@ func TestInsert(t *testing.T) {
 func TestReload(t *testing.T) {
 	t.Run("Chats", testChatsReload)
 	t.Run("ChatPeers", testChatPeersReload)
+	t.Run("ForumTopics", testForumTopicsReload)
+	t.Run("ImportCheckpoints", testImportCheckpointsReload)
+	t.Run("Media", testMediaReload)
 	t.Run("Messages", testMessagesReload)
+	t.Run("MessageEntities", testMessageEntitiesReload)
+	t.Run("MessageRevisions", testMessageRevisionsReload)
 	t.Run("Peers", testPeersReload)
 }
 
 func TestReloadAll(t *testing.T) {
 	t.Run("Chats", testChatsReloadAll)
 	t.Run("ChatPeers", testChatPeersReloadAll)
+	t.Run("ForumTopics", testForumTopicsReloadAll)
+	t.Run("ImportCheckpoints", testImportCheckpointsReloadAll)
+	t.Run("Media", testMediaReloadAll)
 	t.Run("Messages", testMessagesReloadAll)
+	t.Run("MessageEntities", testMessageEntitiesReloadAll)
+	t.Run("MessageRevisions", testMessageRevisionsReloadAll)
 	t.Run("Peers", testPeersReloadAll)
 }
 
 func TestSelect(t *testing.T) {
 	t.Run("Chats", testChatsSelect)
 	t.Run("ChatPeers", testChatPeersSelect)
+	t.Run("ForumTopics", testForumTopicsSelect)
+	t.Run("ImportCheckpoints", testImportCheckpointsSelect)
+	t.Run("Media", testMediaSelect)
 	t.Run("Messages", testMessagesSelect)
+	t.Run("MessageEntities", testMessageEntitiesSelect)
+	t.Run("MessageRevisions", testMessageRevisionsSelect)
 	t.Run("Peers", testPeersSelect)
 }
 
 func TestUpdate(t *testing.T) {
 	t.Run("Chats", testChatsUpdate)
 	t.Run("ChatPeers", testChatPeersUpdate)
+	t.Run("ForumTopics", testForumTopicsUpdate)
+	t.Run("ImportCheckpoints", testImportCheckpointsUpdate)
+	t.Run("Media", testMediaUpdate)
 	t.Run("Messages", testMessagesUpdate)
+	t.Run("MessageEntities", testMessageEntitiesUpdate)
+	t.Run("MessageRevisions", testMessageRevisionsUpdate)
 	t.Run("Peers", testPeersUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
 	t.Run("Chats", testChatsSliceUpdateAll)
 	t.Run("ChatPeers", testChatPeersSliceUpdateAll)
+	t.Run("ForumTopics", testForumTopicsSliceUpdateAll)
+	t.Run("ImportCheckpoints", testImportCheckpointsSliceUpdateAll)
+	t.Run("Media", testMediaSliceUpdateAll)
 	t.Run("Messages", testMessagesSliceUpdateAll)
+	t.Run("MessageEntities", testMessageEntitiesSliceUpdateAll)
+	t.Run("MessageRevisions", testMessageRevisionsSliceUpdateAll)
 	t.Run("Peers", testPeersSliceUpdateAll)
 }
@@ -4,13 +4,23 @@
 package models
 
 var TableNames = struct {
-	Chat     string
-	ChatPeer string
-	Message  string
-	Peer     string
+	Chat             string
+	ChatPeer         string
+	ForumTopic       string
+	ImportCheckpoint string
+	Media            string
+	Message          string
+	MessageEntity    string
+	MessageRevision  string
+	Peer             string
 }{
-	Chat:     "chat",
-	ChatPeer: "chat_peer",
-	Message:  "message",
-	Peer:     "peer",
+	Chat:             "chat",
+	ChatPeer:         "chat_peer",
+	ForumTopic:       "forum_topic",
+	ImportCheckpoint: "import_checkpoint",
+	Media:            "media",
+	Message:          "message",
+	MessageEntity:    "message_entity",
+	MessageRevision:  "message_revision",
+	Peer:             "peer",
 }
@@ -23,32 +23,47 @@ import (
 
 // Chat is an object representing the database table.
 type Chat struct {
-	ID      int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Title   string `boil:"title" json:"title" toml:"title" yaml:"title"`
-	Enabled bool   `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
+	ID        int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Title     string `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Enabled   bool   `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
+	SortOrder string `boil:"sort_order" json:"sort_order" toml:"sort_order" yaml:"sort_order"`
+	Timezone  string `boil:"timezone" json:"timezone" toml:"timezone" yaml:"timezone"`
+	Username  string `boil:"username" json:"username" toml:"username" yaml:"username"`
 
 	R *chatR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L chatL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ChatColumns = struct {
-	ID      string
-	Title   string
-	Enabled string
+	ID        string
+	Title     string
+	Enabled   string
+	SortOrder string
+	Timezone  string
+	Username  string
 }{
-	ID:      "id",
-	Title:   "title",
-	Enabled: "enabled",
+	ID:        "id",
+	Title:     "title",
+	Enabled:   "enabled",
+	SortOrder: "sort_order",
+	Timezone:  "timezone",
+	Username:  "username",
 }
 
 var ChatTableColumns = struct {
-	ID      string
-	Title   string
-	Enabled string
+	ID        string
+	Title     string
+	Enabled   string
+	SortOrder string
+	Timezone  string
+	Username  string
 }{
-	ID:      "chat.id",
-	Title:   "chat.title",
-	Enabled: "chat.enabled",
+	ID:        "chat.id",
+	Title:     "chat.title",
+	Enabled:   "chat.enabled",
+	SortOrder: "chat.sort_order",
+	Timezone:  "chat.timezone",
+	Username:  "chat.username",
 }
 
 // Generated where
@@ -111,13 +126,19 @@ func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
 var ChatWhere = struct {
-	ID      whereHelperint64
-	Title   whereHelperstring
-	Enabled whereHelperbool
+	ID        whereHelperint64
+	Title     whereHelperstring
+	Enabled   whereHelperbool
+	SortOrder whereHelperstring
+	Timezone  whereHelperstring
+	Username  whereHelperstring
 }{
-	ID:      whereHelperint64{field: "\"chat\".\"id\""},
-	Title:   whereHelperstring{field: "\"chat\".\"title\""},
-	Enabled: whereHelperbool{field: "\"chat\".\"enabled\""},
+	ID:        whereHelperint64{field: "\"chat\".\"id\""},
+	Title:     whereHelperstring{field: "\"chat\".\"title\""},
+	Enabled:   whereHelperbool{field: "\"chat\".\"enabled\""},
+	SortOrder: whereHelperstring{field: "\"chat\".\"sort_order\""},
+	Timezone:  whereHelperstring{field: "\"chat\".\"timezone\""},
+	Username:  whereHelperstring{field: "\"chat\".\"username\""},
 }
 
 // ChatRels is where relationship names are stored.
@@ -137,9 +158,9 @@ func (*chatR) NewStruct() *chatR {
 type chatL struct{}
 
 var (
-	chatAllColumns            = []string{"id", "title", "enabled"}
+	chatAllColumns            = []string{"id", "title", "enabled", "sort_order", "timezone", "username"}
 	chatColumnsWithoutDefault = []string{"title", "enabled"}
-	chatColumnsWithDefault    = []string{"id"}
+	chatColumnsWithDefault    = []string{"id", "sort_order", "timezone", "username"}
 	chatPrimaryKeyColumns     = []string{"id"}
 	chatGeneratedColumns      = []string{"id"}
 )
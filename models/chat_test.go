@@ -568,7 +568,7 @@ func testChatsSelect(t *testing.T) {
 }
 
 var (
-	chatDBTypes = map[string]string{`ID`: `INTEGER`, `Title`: `TEXT`, `Enabled`: `BOOLEAN`}
+	chatDBTypes = map[string]string{`ID`: `INTEGER`, `Title`: `TEXT`, `Enabled`: `BOOLEAN`, `SortOrder`: `TEXT`, `Timezone`: `TEXT`, `Username`: `TEXT`}
 	_           = bytes.MinRead
 )
 
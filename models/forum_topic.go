@@ -0,0 +1,930 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ForumTopic is an object representing the database table.
+type ForumTopic struct {
+	ChatID   int64  `boil:"chat_id" json:"chat_id" toml:"chat_id" yaml:"chat_id"`
+	ThreadID int64  `boil:"thread_id" json:"thread_id" toml:"thread_id" yaml:"thread_id"`
+	Name     string `boil:"name" json:"name" toml:"name" yaml:"name"`
+
+	R *forumTopicR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L forumTopicL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ForumTopicColumns = struct {
+	ChatID   string
+	ThreadID string
+	Name     string
+}{
+	ChatID:   "chat_id",
+	ThreadID: "thread_id",
+	Name:     "name",
+}
+
+var ForumTopicTableColumns = struct {
+	ChatID   string
+	ThreadID string
+	Name     string
+}{
+	ChatID:   "forum_topic.chat_id",
+	ThreadID: "forum_topic.thread_id",
+	Name:     "forum_topic.name",
+}
+
+// Generated where
+
+var ForumTopicWhere = struct {
+	ChatID   whereHelperint64
+	ThreadID whereHelperint64
+	Name     whereHelperstring
+}{
+	ChatID:   whereHelperint64{field: "\"forum_topic\".\"chat_id\""},
+	ThreadID: whereHelperint64{field: "\"forum_topic\".\"thread_id\""},
+	Name:     whereHelperstring{field: "\"forum_topic\".\"name\""},
+}
+
+// ForumTopicRels is where relationship names are stored.
+var ForumTopicRels = struct {
+}{}
+
+// forumTopicR is where relationships are stored.
+type forumTopicR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*forumTopicR) NewStruct() *forumTopicR {
+	return &forumTopicR{}
+}
+
+// forumTopicL is where Load methods for each relationship are stored.
+type forumTopicL struct{}
+
+var (
+	forumTopicAllColumns            = []string{"chat_id", "thread_id", "name"}
+	forumTopicColumnsWithoutDefault = []string{"chat_id", "thread_id", "name"}
+	forumTopicColumnsWithDefault    = []string{}
+	forumTopicPrimaryKeyColumns     = []string{"chat_id", "thread_id"}
+	forumTopicGeneratedColumns      = []string{}
+)
+
+type (
+	// ForumTopicSlice is an alias for a slice of pointers to ForumTopic.
+	// This should almost always be used instead of []ForumTopic.
+	ForumTopicSlice []*ForumTopic
+	// ForumTopicHook is the signature for custom ForumTopic hook methods
+	ForumTopicHook func(context.Context, boil.ContextExecutor, *ForumTopic) error
+
+	forumTopicQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	forumTopicType                 = reflect.TypeOf(&ForumTopic{})
+	forumTopicMapping              = queries.MakeStructMapping(forumTopicType)
+	forumTopicPrimaryKeyMapping, _ = queries.BindMapping(forumTopicType, forumTopicMapping, forumTopicPrimaryKeyColumns)
+	forumTopicInsertCacheMut       sync.RWMutex
+	forumTopicInsertCache          = make(map[string]insertCache)
+	forumTopicUpdateCacheMut       sync.RWMutex
+	forumTopicUpdateCache          = make(map[string]updateCache)
+	forumTopicUpsertCacheMut       sync.RWMutex
+	forumTopicUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var forumTopicAfterSelectMu sync.Mutex
+var forumTopicAfterSelectHooks []ForumTopicHook
+
+var forumTopicBeforeInsertMu sync.Mutex
+var forumTopicBeforeInsertHooks []ForumTopicHook
+var forumTopicAfterInsertMu sync.Mutex
+var forumTopicAfterInsertHooks []ForumTopicHook
+
+var forumTopicBeforeUpdateMu sync.Mutex
+var forumTopicBeforeUpdateHooks []ForumTopicHook
+var forumTopicAfterUpdateMu sync.Mutex
+var forumTopicAfterUpdateHooks []ForumTopicHook
+
+var forumTopicBeforeDeleteMu sync.Mutex
+var forumTopicBeforeDeleteHooks []ForumTopicHook
+var forumTopicAfterDeleteMu sync.Mutex
+var forumTopicAfterDeleteHooks []ForumTopicHook
+
+var forumTopicBeforeUpsertMu sync.Mutex
+var forumTopicBeforeUpsertHooks []ForumTopicHook
+var forumTopicAfterUpsertMu sync.Mutex
+var forumTopicAfterUpsertHooks []ForumTopicHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ForumTopic) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ForumTopic) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ForumTopic) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ForumTopic) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ForumTopic) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ForumTopic) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ForumTopic) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ForumTopic) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ForumTopic) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forumTopicAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddForumTopicHook registers your hook function for all future operations.
+func AddForumTopicHook(hookPoint boil.HookPoint, forumTopicHook ForumTopicHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		forumTopicAfterSelectMu.Lock()
+		forumTopicAfterSelectHooks = append(forumTopicAfterSelectHooks, forumTopicHook)
+		forumTopicAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		forumTopicBeforeInsertMu.Lock()
+		forumTopicBeforeInsertHooks = append(forumTopicBeforeInsertHooks, forumTopicHook)
+		forumTopicBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		forumTopicAfterInsertMu.Lock()
+		forumTopicAfterInsertHooks = append(forumTopicAfterInsertHooks, forumTopicHook)
+		forumTopicAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		forumTopicBeforeUpdateMu.Lock()
+		forumTopicBeforeUpdateHooks = append(forumTopicBeforeUpdateHooks, forumTopicHook)
+		forumTopicBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		forumTopicAfterUpdateMu.Lock()
+		forumTopicAfterUpdateHooks = append(forumTopicAfterUpdateHooks, forumTopicHook)
+		forumTopicAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		forumTopicBeforeDeleteMu.Lock()
+		forumTopicBeforeDeleteHooks = append(forumTopicBeforeDeleteHooks, forumTopicHook)
+		forumTopicBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		forumTopicAfterDeleteMu.Lock()
+		forumTopicAfterDeleteHooks = append(forumTopicAfterDeleteHooks, forumTopicHook)
+		forumTopicAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		forumTopicBeforeUpsertMu.Lock()
+		forumTopicBeforeUpsertHooks = append(forumTopicBeforeUpsertHooks, forumTopicHook)
+		forumTopicBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		forumTopicAfterUpsertMu.Lock()
+		forumTopicAfterUpsertHooks = append(forumTopicAfterUpsertHooks, forumTopicHook)
+		forumTopicAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single forumTopic record from the query.
+func (q forumTopicQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ForumTopic, error) {
+	o := &ForumTopic{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for forum_topic")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ForumTopic records from the query.
+func (q forumTopicQuery) All(ctx context.Context, exec boil.ContextExecutor) (ForumTopicSlice, error) {
+	var o []*ForumTopic
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ForumTopic slice")
+	}
+
+	if len(forumTopicAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ForumTopic records in the query.
+func (q forumTopicQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count forum_topic rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q forumTopicQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if forum_topic exists")
+	}
+
+	return count > 0, nil
+}
+
+// ForumTopics retrieves all the records using an executor.
+func ForumTopics(mods ...qm.QueryMod) forumTopicQuery {
+	mods = append(mods, qm.From("\"forum_topic\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"forum_topic\".*"})
+	}
+
+	return forumTopicQuery{q}
+}
+
+// FindForumTopic retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindForumTopic(ctx context.Context, exec boil.ContextExecutor, chatID int64, threadID int64, selectCols ...string) (*ForumTopic, error) {
+	forumTopicObj := &ForumTopic{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"forum_topic\" where \"chat_id\"=? AND \"thread_id\"=?", sel,
+	)
+
+	q := queries.Raw(query, chatID, threadID)
+
+	err := q.Bind(ctx, exec, forumTopicObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from forum_topic")
+	}
+
+	if err = forumTopicObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return forumTopicObj, err
+	}
+
+	return forumTopicObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ForumTopic) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no forum_topic provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(forumTopicColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	forumTopicInsertCacheMut.RLock()
+	cache, cached := forumTopicInsertCache[key]
+	forumTopicInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			forumTopicAllColumns,
+			forumTopicColumnsWithDefault,
+			forumTopicColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(forumTopicType, forumTopicMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(forumTopicType, forumTopicMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"forum_topic\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"forum_topic\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into forum_topic")
+	}
+
+	if !cached {
+		forumTopicInsertCacheMut.Lock()
+		forumTopicInsertCache[key] = cache
+		forumTopicInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ForumTopic.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ForumTopic) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	forumTopicUpdateCacheMut.RLock()
+	cache, cached := forumTopicUpdateCache[key]
+	forumTopicUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			forumTopicAllColumns,
+			forumTopicPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update forum_topic, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"forum_topic\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, forumTopicPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(forumTopicType, forumTopicMapping, append(wl, forumTopicPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update forum_topic row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for forum_topic")
+	}
+
+	if !cached {
+		forumTopicUpdateCacheMut.Lock()
+		forumTopicUpdateCache[key] = cache
+		forumTopicUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q forumTopicQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for forum_topic")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for forum_topic")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ForumTopicSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forumTopicPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"forum_topic\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, forumTopicPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in forumTopic slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all forumTopic")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ForumTopic) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no forum_topic provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(forumTopicColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	forumTopicUpsertCacheMut.RLock()
+	cache, cached := forumTopicUpsertCache[key]
+	forumTopicUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			forumTopicAllColumns,
+			forumTopicColumnsWithDefault,
+			forumTopicColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			forumTopicAllColumns,
+			forumTopicPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert forum_topic, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(forumTopicAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(forumTopicPrimaryKeyColumns))
+			copy(conflict, forumTopicPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"forum_topic\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(forumTopicType, forumTopicMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(forumTopicType, forumTopicMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert forum_topic")
+	}
+
+	if !cached {
+		forumTopicUpsertCacheMut.Lock()
+		forumTopicUpsertCache[key] = cache
+		forumTopicUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ForumTopic record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ForumTopic) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ForumTopic provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), forumTopicPrimaryKeyMapping)
+	sql := "DELETE FROM \"forum_topic\" WHERE \"chat_id\"=? AND \"thread_id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from forum_topic")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for forum_topic")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q forumTopicQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no forumTopicQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from forum_topic")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for forum_topic")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ForumTopicSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(forumTopicBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forumTopicPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"forum_topic\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, forumTopicPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from forumTopic slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for forum_topic")
+	}
+
+	if len(forumTopicAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ForumTopic) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindForumTopic(ctx, exec, o.ChatID, o.ThreadID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ForumTopicSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ForumTopicSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forumTopicPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"forum_topic\".* FROM \"forum_topic\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, forumTopicPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ForumTopicSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ForumTopicExists checks if the ForumTopic row exists.
+func ForumTopicExists(ctx context.Context, exec boil.ContextExecutor, chatID int64, threadID int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"forum_topic\" where \"chat_id\"=? AND \"thread_id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, chatID, threadID)
+	}
+	row := exec.QueryRowContext(ctx, sql, chatID, threadID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if forum_topic exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ForumTopic row exists.
+func (o *ForumTopic) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ForumTopicExists(ctx, exec, o.ChatID, o.ThreadID)
+}
@@ -0,0 +1,731 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testForumTopics(t *testing.T) {
+	t.Parallel()
+
+	query := ForumTopics()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testForumTopicsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testForumTopicsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := ForumTopics().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testForumTopicsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ForumTopicSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testForumTopicsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ForumTopicExists(ctx, tx, o.ChatID, o.ThreadID)
+	if err != nil {
+		t.Errorf("Unable to check if ForumTopic exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ForumTopicExists to return true, but got false.")
+	}
+}
+
+func testForumTopicsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	forumTopicFound, err := FindForumTopic(ctx, tx, o.ChatID, o.ThreadID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if forumTopicFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testForumTopicsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = ForumTopics().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testForumTopicsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := ForumTopics().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testForumTopicsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	forumTopicOne := &ForumTopic{}
+	forumTopicTwo := &ForumTopic{}
+	if err = randomize.Struct(seed, forumTopicOne, forumTopicDBTypes, false, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+	if err = randomize.Struct(seed, forumTopicTwo, forumTopicDBTypes, false, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = forumTopicOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = forumTopicTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ForumTopics().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testForumTopicsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	forumTopicOne := &ForumTopic{}
+	forumTopicTwo := &ForumTopic{}
+	if err = randomize.Struct(seed, forumTopicOne, forumTopicDBTypes, false, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+	if err = randomize.Struct(seed, forumTopicTwo, forumTopicDBTypes, false, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = forumTopicOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = forumTopicTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func forumTopicBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func forumTopicAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ForumTopic) error {
+	*o = ForumTopic{}
+	return nil
+}
+
+func testForumTopicsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &ForumTopic{}
+	o := &ForumTopic{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize ForumTopic object: %s", err)
+	}
+
+	AddForumTopicHook(boil.BeforeInsertHook, forumTopicBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	forumTopicBeforeInsertHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.AfterInsertHook, forumTopicAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	forumTopicAfterInsertHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.AfterSelectHook, forumTopicAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	forumTopicAfterSelectHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.BeforeUpdateHook, forumTopicBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	forumTopicBeforeUpdateHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.AfterUpdateHook, forumTopicAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	forumTopicAfterUpdateHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.BeforeDeleteHook, forumTopicBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	forumTopicBeforeDeleteHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.AfterDeleteHook, forumTopicAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	forumTopicAfterDeleteHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.BeforeUpsertHook, forumTopicBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	forumTopicBeforeUpsertHooks = []ForumTopicHook{}
+
+	AddForumTopicHook(boil.AfterUpsertHook, forumTopicAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	forumTopicAfterUpsertHooks = []ForumTopicHook{}
+}
+
+func testForumTopicsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testForumTopicsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(forumTopicColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testForumTopicsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testForumTopicsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ForumTopicSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testForumTopicsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ForumTopics().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	forumTopicDBTypes = map[string]string{`ChatID`: `INTEGER`, `ThreadID`: `INTEGER`, `Name`: `TEXT`}
+	_                 = bytes.MinRead
+)
+
+func testForumTopicsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(forumTopicPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(forumTopicAllColumns) == len(forumTopicPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testForumTopicsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(forumTopicAllColumns) == len(forumTopicPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForumTopic{}
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, forumTopicDBTypes, true, forumTopicPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(forumTopicAllColumns, forumTopicPrimaryKeyColumns) {
+		fields = forumTopicAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			forumTopicAllColumns,
+			forumTopicPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ForumTopicSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testForumTopicsUpsert(t *testing.T) {
+	t.Parallel()
+	if len(forumTopicAllColumns) == len(forumTopicPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := ForumTopic{}
+	if err = randomize.Struct(seed, &o, forumTopicDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ForumTopic: %s", err)
+	}
+
+	count, err := ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, forumTopicDBTypes, false, forumTopicPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ForumTopic struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ForumTopic: %s", err)
+	}
+
+	count, err = ForumTopics().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
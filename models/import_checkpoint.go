@@ -0,0 +1,976 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ImportCheckpoint is an object representing the database table.
+type ImportCheckpoint struct {
+	ChatID    int64     `boil:"chat_id" json:"chat_id" toml:"chat_id" yaml:"chat_id"`
+	Source    string    `boil:"source" json:"source" toml:"source" yaml:"source"`
+	LastMSGID int64     `boil:"last_msg_id" json:"last_msg_id" toml:"last_msg_id" yaml:"last_msg_id"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *importCheckpointR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L importCheckpointL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ImportCheckpointColumns = struct {
+	ChatID    string
+	Source    string
+	LastMSGID string
+	UpdatedAt string
+}{
+	ChatID:    "chat_id",
+	Source:    "source",
+	LastMSGID: "last_msg_id",
+	UpdatedAt: "updated_at",
+}
+
+var ImportCheckpointTableColumns = struct {
+	ChatID    string
+	Source    string
+	LastMSGID string
+	UpdatedAt string
+}{
+	ChatID:    "import_checkpoint.chat_id",
+	Source:    "import_checkpoint.source",
+	LastMSGID: "import_checkpoint.last_msg_id",
+	UpdatedAt: "import_checkpoint.updated_at",
+}
+
+// Generated where
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var ImportCheckpointWhere = struct {
+	ChatID    whereHelperint64
+	Source    whereHelperstring
+	LastMSGID whereHelperint64
+	UpdatedAt whereHelpertime_Time
+}{
+	ChatID:    whereHelperint64{field: "\"import_checkpoint\".\"chat_id\""},
+	Source:    whereHelperstring{field: "\"import_checkpoint\".\"source\""},
+	LastMSGID: whereHelperint64{field: "\"import_checkpoint\".\"last_msg_id\""},
+	UpdatedAt: whereHelpertime_Time{field: "\"import_checkpoint\".\"updated_at\""},
+}
+
+// ImportCheckpointRels is where relationship names are stored.
+var ImportCheckpointRels = struct {
+}{}
+
+// importCheckpointR is where relationships are stored.
+type importCheckpointR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*importCheckpointR) NewStruct() *importCheckpointR {
+	return &importCheckpointR{}
+}
+
+// importCheckpointL is where Load methods for each relationship are stored.
+type importCheckpointL struct{}
+
+var (
+	importCheckpointAllColumns            = []string{"chat_id", "source", "last_msg_id", "updated_at"}
+	importCheckpointColumnsWithoutDefault = []string{"chat_id", "source", "last_msg_id", "updated_at"}
+	importCheckpointColumnsWithDefault    = []string{}
+	importCheckpointPrimaryKeyColumns     = []string{"chat_id", "source"}
+	importCheckpointGeneratedColumns      = []string{}
+)
+
+type (
+	// ImportCheckpointSlice is an alias for a slice of pointers to ImportCheckpoint.
+	// This should almost always be used instead of []ImportCheckpoint.
+	ImportCheckpointSlice []*ImportCheckpoint
+	// ImportCheckpointHook is the signature for custom ImportCheckpoint hook methods
+	ImportCheckpointHook func(context.Context, boil.ContextExecutor, *ImportCheckpoint) error
+
+	importCheckpointQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	importCheckpointType                 = reflect.TypeOf(&ImportCheckpoint{})
+	importCheckpointMapping              = queries.MakeStructMapping(importCheckpointType)
+	importCheckpointPrimaryKeyMapping, _ = queries.BindMapping(importCheckpointType, importCheckpointMapping, importCheckpointPrimaryKeyColumns)
+	importCheckpointInsertCacheMut       sync.RWMutex
+	importCheckpointInsertCache          = make(map[string]insertCache)
+	importCheckpointUpdateCacheMut       sync.RWMutex
+	importCheckpointUpdateCache          = make(map[string]updateCache)
+	importCheckpointUpsertCacheMut       sync.RWMutex
+	importCheckpointUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var importCheckpointAfterSelectMu sync.Mutex
+var importCheckpointAfterSelectHooks []ImportCheckpointHook
+
+var importCheckpointBeforeInsertMu sync.Mutex
+var importCheckpointBeforeInsertHooks []ImportCheckpointHook
+var importCheckpointAfterInsertMu sync.Mutex
+var importCheckpointAfterInsertHooks []ImportCheckpointHook
+
+var importCheckpointBeforeUpdateMu sync.Mutex
+var importCheckpointBeforeUpdateHooks []ImportCheckpointHook
+var importCheckpointAfterUpdateMu sync.Mutex
+var importCheckpointAfterUpdateHooks []ImportCheckpointHook
+
+var importCheckpointBeforeDeleteMu sync.Mutex
+var importCheckpointBeforeDeleteHooks []ImportCheckpointHook
+var importCheckpointAfterDeleteMu sync.Mutex
+var importCheckpointAfterDeleteHooks []ImportCheckpointHook
+
+var importCheckpointBeforeUpsertMu sync.Mutex
+var importCheckpointBeforeUpsertHooks []ImportCheckpointHook
+var importCheckpointAfterUpsertMu sync.Mutex
+var importCheckpointAfterUpsertHooks []ImportCheckpointHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ImportCheckpoint) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ImportCheckpoint) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ImportCheckpoint) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ImportCheckpoint) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ImportCheckpoint) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ImportCheckpoint) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ImportCheckpoint) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ImportCheckpoint) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ImportCheckpoint) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range importCheckpointAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddImportCheckpointHook registers your hook function for all future operations.
+func AddImportCheckpointHook(hookPoint boil.HookPoint, importCheckpointHook ImportCheckpointHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		importCheckpointAfterSelectMu.Lock()
+		importCheckpointAfterSelectHooks = append(importCheckpointAfterSelectHooks, importCheckpointHook)
+		importCheckpointAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		importCheckpointBeforeInsertMu.Lock()
+		importCheckpointBeforeInsertHooks = append(importCheckpointBeforeInsertHooks, importCheckpointHook)
+		importCheckpointBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		importCheckpointAfterInsertMu.Lock()
+		importCheckpointAfterInsertHooks = append(importCheckpointAfterInsertHooks, importCheckpointHook)
+		importCheckpointAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		importCheckpointBeforeUpdateMu.Lock()
+		importCheckpointBeforeUpdateHooks = append(importCheckpointBeforeUpdateHooks, importCheckpointHook)
+		importCheckpointBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		importCheckpointAfterUpdateMu.Lock()
+		importCheckpointAfterUpdateHooks = append(importCheckpointAfterUpdateHooks, importCheckpointHook)
+		importCheckpointAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		importCheckpointBeforeDeleteMu.Lock()
+		importCheckpointBeforeDeleteHooks = append(importCheckpointBeforeDeleteHooks, importCheckpointHook)
+		importCheckpointBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		importCheckpointAfterDeleteMu.Lock()
+		importCheckpointAfterDeleteHooks = append(importCheckpointAfterDeleteHooks, importCheckpointHook)
+		importCheckpointAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		importCheckpointBeforeUpsertMu.Lock()
+		importCheckpointBeforeUpsertHooks = append(importCheckpointBeforeUpsertHooks, importCheckpointHook)
+		importCheckpointBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		importCheckpointAfterUpsertMu.Lock()
+		importCheckpointAfterUpsertHooks = append(importCheckpointAfterUpsertHooks, importCheckpointHook)
+		importCheckpointAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single importCheckpoint record from the query.
+func (q importCheckpointQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ImportCheckpoint, error) {
+	o := &ImportCheckpoint{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for import_checkpoint")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ImportCheckpoint records from the query.
+func (q importCheckpointQuery) All(ctx context.Context, exec boil.ContextExecutor) (ImportCheckpointSlice, error) {
+	var o []*ImportCheckpoint
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ImportCheckpoint slice")
+	}
+
+	if len(importCheckpointAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ImportCheckpoint records in the query.
+func (q importCheckpointQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count import_checkpoint rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q importCheckpointQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if import_checkpoint exists")
+	}
+
+	return count > 0, nil
+}
+
+// ImportCheckpoints retrieves all the records using an executor.
+func ImportCheckpoints(mods ...qm.QueryMod) importCheckpointQuery {
+	mods = append(mods, qm.From("\"import_checkpoint\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"import_checkpoint\".*"})
+	}
+
+	return importCheckpointQuery{q}
+}
+
+// FindImportCheckpoint retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindImportCheckpoint(ctx context.Context, exec boil.ContextExecutor, chatID int64, source string, selectCols ...string) (*ImportCheckpoint, error) {
+	importCheckpointObj := &ImportCheckpoint{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"import_checkpoint\" where \"chat_id\"=? AND \"source\"=?", sel,
+	)
+
+	q := queries.Raw(query, chatID, source)
+
+	err := q.Bind(ctx, exec, importCheckpointObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from import_checkpoint")
+	}
+
+	if err = importCheckpointObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return importCheckpointObj, err
+	}
+
+	return importCheckpointObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ImportCheckpoint) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no import_checkpoint provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(importCheckpointColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	importCheckpointInsertCacheMut.RLock()
+	cache, cached := importCheckpointInsertCache[key]
+	importCheckpointInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			importCheckpointAllColumns,
+			importCheckpointColumnsWithDefault,
+			importCheckpointColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(importCheckpointType, importCheckpointMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(importCheckpointType, importCheckpointMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"import_checkpoint\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"import_checkpoint\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into import_checkpoint")
+	}
+
+	if !cached {
+		importCheckpointInsertCacheMut.Lock()
+		importCheckpointInsertCache[key] = cache
+		importCheckpointInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ImportCheckpoint.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ImportCheckpoint) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	importCheckpointUpdateCacheMut.RLock()
+	cache, cached := importCheckpointUpdateCache[key]
+	importCheckpointUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			importCheckpointAllColumns,
+			importCheckpointPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update import_checkpoint, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"import_checkpoint\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, importCheckpointPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(importCheckpointType, importCheckpointMapping, append(wl, importCheckpointPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update import_checkpoint row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for import_checkpoint")
+	}
+
+	if !cached {
+		importCheckpointUpdateCacheMut.Lock()
+		importCheckpointUpdateCache[key] = cache
+		importCheckpointUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q importCheckpointQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for import_checkpoint")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for import_checkpoint")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ImportCheckpointSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), importCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"import_checkpoint\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, importCheckpointPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in importCheckpoint slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all importCheckpoint")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ImportCheckpoint) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no import_checkpoint provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(importCheckpointColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	importCheckpointUpsertCacheMut.RLock()
+	cache, cached := importCheckpointUpsertCache[key]
+	importCheckpointUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			importCheckpointAllColumns,
+			importCheckpointColumnsWithDefault,
+			importCheckpointColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			importCheckpointAllColumns,
+			importCheckpointPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert import_checkpoint, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(importCheckpointAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(importCheckpointPrimaryKeyColumns))
+			copy(conflict, importCheckpointPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"import_checkpoint\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(importCheckpointType, importCheckpointMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(importCheckpointType, importCheckpointMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert import_checkpoint")
+	}
+
+	if !cached {
+		importCheckpointUpsertCacheMut.Lock()
+		importCheckpointUpsertCache[key] = cache
+		importCheckpointUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ImportCheckpoint record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ImportCheckpoint) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ImportCheckpoint provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), importCheckpointPrimaryKeyMapping)
+	sql := "DELETE FROM \"import_checkpoint\" WHERE \"chat_id\"=? AND \"source\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from import_checkpoint")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for import_checkpoint")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q importCheckpointQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no importCheckpointQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from import_checkpoint")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for import_checkpoint")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ImportCheckpointSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(importCheckpointBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), importCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"import_checkpoint\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, importCheckpointPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from importCheckpoint slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for import_checkpoint")
+	}
+
+	if len(importCheckpointAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ImportCheckpoint) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindImportCheckpoint(ctx, exec, o.ChatID, o.Source)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ImportCheckpointSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ImportCheckpointSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), importCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"import_checkpoint\".* FROM \"import_checkpoint\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, importCheckpointPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ImportCheckpointSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ImportCheckpointExists checks if the ImportCheckpoint row exists.
+func ImportCheckpointExists(ctx context.Context, exec boil.ContextExecutor, chatID int64, source string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"import_checkpoint\" where \"chat_id\"=? AND \"source\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, chatID, source)
+	}
+	row := exec.QueryRowContext(ctx, sql, chatID, source)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if import_checkpoint exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ImportCheckpoint row exists.
+func (o *ImportCheckpoint) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ImportCheckpointExists(ctx, exec, o.ChatID, o.Source)
+}
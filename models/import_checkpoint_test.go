@@ -0,0 +1,731 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testImportCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	query := ImportCheckpoints()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testImportCheckpointsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testImportCheckpointsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := ImportCheckpoints().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testImportCheckpointsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ImportCheckpointSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testImportCheckpointsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ImportCheckpointExists(ctx, tx, o.ChatID, o.Source)
+	if err != nil {
+		t.Errorf("Unable to check if ImportCheckpoint exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ImportCheckpointExists to return true, but got false.")
+	}
+}
+
+func testImportCheckpointsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	importCheckpointFound, err := FindImportCheckpoint(ctx, tx, o.ChatID, o.Source)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if importCheckpointFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testImportCheckpointsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = ImportCheckpoints().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testImportCheckpointsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := ImportCheckpoints().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testImportCheckpointsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	importCheckpointOne := &ImportCheckpoint{}
+	importCheckpointTwo := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, importCheckpointOne, importCheckpointDBTypes, false, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+	if err = randomize.Struct(seed, importCheckpointTwo, importCheckpointDBTypes, false, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = importCheckpointOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = importCheckpointTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ImportCheckpoints().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testImportCheckpointsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	importCheckpointOne := &ImportCheckpoint{}
+	importCheckpointTwo := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, importCheckpointOne, importCheckpointDBTypes, false, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+	if err = randomize.Struct(seed, importCheckpointTwo, importCheckpointDBTypes, false, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = importCheckpointOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = importCheckpointTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func importCheckpointBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func importCheckpointAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ImportCheckpoint) error {
+	*o = ImportCheckpoint{}
+	return nil
+}
+
+func testImportCheckpointsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &ImportCheckpoint{}
+	o := &ImportCheckpoint{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint object: %s", err)
+	}
+
+	AddImportCheckpointHook(boil.BeforeInsertHook, importCheckpointBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointBeforeInsertHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.AfterInsertHook, importCheckpointAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointAfterInsertHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.AfterSelectHook, importCheckpointAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointAfterSelectHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.BeforeUpdateHook, importCheckpointBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointBeforeUpdateHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.AfterUpdateHook, importCheckpointAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointAfterUpdateHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.BeforeDeleteHook, importCheckpointBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointBeforeDeleteHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.AfterDeleteHook, importCheckpointAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointAfterDeleteHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.BeforeUpsertHook, importCheckpointBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointBeforeUpsertHooks = []ImportCheckpointHook{}
+
+	AddImportCheckpointHook(boil.AfterUpsertHook, importCheckpointAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	importCheckpointAfterUpsertHooks = []ImportCheckpointHook{}
+}
+
+func testImportCheckpointsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testImportCheckpointsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(importCheckpointColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testImportCheckpointsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testImportCheckpointsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ImportCheckpointSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testImportCheckpointsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ImportCheckpoints().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	importCheckpointDBTypes = map[string]string{`ChatID`: `INTEGER`, `Source`: `TEXT`, `LastMSGID`: `INTEGER`, `UpdatedAt`: `DATETIME`}
+	_                       = bytes.MinRead
+)
+
+func testImportCheckpointsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(importCheckpointPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(importCheckpointAllColumns) == len(importCheckpointPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testImportCheckpointsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(importCheckpointAllColumns) == len(importCheckpointPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ImportCheckpoint{}
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, importCheckpointDBTypes, true, importCheckpointPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(importCheckpointAllColumns, importCheckpointPrimaryKeyColumns) {
+		fields = importCheckpointAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			importCheckpointAllColumns,
+			importCheckpointPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ImportCheckpointSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testImportCheckpointsUpsert(t *testing.T) {
+	t.Parallel()
+	if len(importCheckpointAllColumns) == len(importCheckpointPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := ImportCheckpoint{}
+	if err = randomize.Struct(seed, &o, importCheckpointDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ImportCheckpoint: %s", err)
+	}
+
+	count, err := ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, importCheckpointDBTypes, false, importCheckpointPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ImportCheckpoint struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ImportCheckpoint: %s", err)
+	}
+
+	count, err = ImportCheckpoints().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,965 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Medium is an object representing the database table.
+type Medium struct {
+	MessageID    string `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
+	Type         string `boil:"type" json:"type" toml:"type" yaml:"type"`
+	FileID       string `boil:"file_id" json:"file_id" toml:"file_id" yaml:"file_id"`
+	FileUniqueID string `boil:"file_unique_id" json:"file_unique_id" toml:"file_unique_id" yaml:"file_unique_id"`
+	FileName     string `boil:"file_name" json:"file_name" toml:"file_name" yaml:"file_name"`
+	MimeType     string `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
+	FileSize     int64  `boil:"file_size" json:"file_size" toml:"file_size" yaml:"file_size"`
+	Duration     int64  `boil:"duration" json:"duration" toml:"duration" yaml:"duration"`
+
+	R *mediumR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L mediumL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var MediumColumns = struct {
+	MessageID    string
+	Type         string
+	FileID       string
+	FileUniqueID string
+	FileName     string
+	MimeType     string
+	FileSize     string
+	Duration     string
+}{
+	MessageID:    "message_id",
+	Type:         "type",
+	FileID:       "file_id",
+	FileUniqueID: "file_unique_id",
+	FileName:     "file_name",
+	MimeType:     "mime_type",
+	FileSize:     "file_size",
+	Duration:     "duration",
+}
+
+var MediumTableColumns = struct {
+	MessageID    string
+	Type         string
+	FileID       string
+	FileUniqueID string
+	FileName     string
+	MimeType     string
+	FileSize     string
+	Duration     string
+}{
+	MessageID:    "media.message_id",
+	Type:         "media.type",
+	FileID:       "media.file_id",
+	FileUniqueID: "media.file_unique_id",
+	FileName:     "media.file_name",
+	MimeType:     "media.mime_type",
+	FileSize:     "media.file_size",
+	Duration:     "media.duration",
+}
+
+// Generated where
+
+var MediumWhere = struct {
+	MessageID    whereHelperstring
+	Type         whereHelperstring
+	FileID       whereHelperstring
+	FileUniqueID whereHelperstring
+	FileName     whereHelperstring
+	MimeType     whereHelperstring
+	FileSize     whereHelperint64
+	Duration     whereHelperint64
+}{
+	MessageID:    whereHelperstring{field: "\"media\".\"message_id\""},
+	Type:         whereHelperstring{field: "\"media\".\"type\""},
+	FileID:       whereHelperstring{field: "\"media\".\"file_id\""},
+	FileUniqueID: whereHelperstring{field: "\"media\".\"file_unique_id\""},
+	FileName:     whereHelperstring{field: "\"media\".\"file_name\""},
+	MimeType:     whereHelperstring{field: "\"media\".\"mime_type\""},
+	FileSize:     whereHelperint64{field: "\"media\".\"file_size\""},
+	Duration:     whereHelperint64{field: "\"media\".\"duration\""},
+}
+
+// MediumRels is where relationship names are stored.
+var MediumRels = struct {
+}{}
+
+// mediumR is where relationships are stored.
+type mediumR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*mediumR) NewStruct() *mediumR {
+	return &mediumR{}
+}
+
+// mediumL is where Load methods for each relationship are stored.
+type mediumL struct{}
+
+var (
+	mediumAllColumns            = []string{"message_id", "type", "file_id", "file_unique_id", "file_name", "mime_type", "file_size", "duration"}
+	mediumColumnsWithoutDefault = []string{"message_id", "type"}
+	mediumColumnsWithDefault    = []string{"file_id", "file_unique_id", "file_name", "mime_type", "file_size", "duration"}
+	mediumPrimaryKeyColumns     = []string{"message_id"}
+	mediumGeneratedColumns      = []string{}
+)
+
+type (
+	// MediumSlice is an alias for a slice of pointers to Medium.
+	// This should almost always be used instead of []Medium.
+	MediumSlice []*Medium
+	// MediumHook is the signature for custom Medium hook methods
+	MediumHook func(context.Context, boil.ContextExecutor, *Medium) error
+
+	mediumQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	mediumType                 = reflect.TypeOf(&Medium{})
+	mediumMapping              = queries.MakeStructMapping(mediumType)
+	mediumPrimaryKeyMapping, _ = queries.BindMapping(mediumType, mediumMapping, mediumPrimaryKeyColumns)
+	mediumInsertCacheMut       sync.RWMutex
+	mediumInsertCache          = make(map[string]insertCache)
+	mediumUpdateCacheMut       sync.RWMutex
+	mediumUpdateCache          = make(map[string]updateCache)
+	mediumUpsertCacheMut       sync.RWMutex
+	mediumUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var mediumAfterSelectMu sync.Mutex
+var mediumAfterSelectHooks []MediumHook
+
+var mediumBeforeInsertMu sync.Mutex
+var mediumBeforeInsertHooks []MediumHook
+var mediumAfterInsertMu sync.Mutex
+var mediumAfterInsertHooks []MediumHook
+
+var mediumBeforeUpdateMu sync.Mutex
+var mediumBeforeUpdateHooks []MediumHook
+var mediumAfterUpdateMu sync.Mutex
+var mediumAfterUpdateHooks []MediumHook
+
+var mediumBeforeDeleteMu sync.Mutex
+var mediumBeforeDeleteHooks []MediumHook
+var mediumAfterDeleteMu sync.Mutex
+var mediumAfterDeleteHooks []MediumHook
+
+var mediumBeforeUpsertMu sync.Mutex
+var mediumBeforeUpsertHooks []MediumHook
+var mediumAfterUpsertMu sync.Mutex
+var mediumAfterUpsertHooks []MediumHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Medium) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Medium) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Medium) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Medium) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Medium) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Medium) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Medium) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Medium) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Medium) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range mediumAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddMediumHook registers your hook function for all future operations.
+func AddMediumHook(hookPoint boil.HookPoint, mediumHook MediumHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		mediumAfterSelectMu.Lock()
+		mediumAfterSelectHooks = append(mediumAfterSelectHooks, mediumHook)
+		mediumAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		mediumBeforeInsertMu.Lock()
+		mediumBeforeInsertHooks = append(mediumBeforeInsertHooks, mediumHook)
+		mediumBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		mediumAfterInsertMu.Lock()
+		mediumAfterInsertHooks = append(mediumAfterInsertHooks, mediumHook)
+		mediumAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		mediumBeforeUpdateMu.Lock()
+		mediumBeforeUpdateHooks = append(mediumBeforeUpdateHooks, mediumHook)
+		mediumBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		mediumAfterUpdateMu.Lock()
+		mediumAfterUpdateHooks = append(mediumAfterUpdateHooks, mediumHook)
+		mediumAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		mediumBeforeDeleteMu.Lock()
+		mediumBeforeDeleteHooks = append(mediumBeforeDeleteHooks, mediumHook)
+		mediumBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		mediumAfterDeleteMu.Lock()
+		mediumAfterDeleteHooks = append(mediumAfterDeleteHooks, mediumHook)
+		mediumAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		mediumBeforeUpsertMu.Lock()
+		mediumBeforeUpsertHooks = append(mediumBeforeUpsertHooks, mediumHook)
+		mediumBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		mediumAfterUpsertMu.Lock()
+		mediumAfterUpsertHooks = append(mediumAfterUpsertHooks, mediumHook)
+		mediumAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single medium record from the query.
+func (q mediumQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Medium, error) {
+	o := &Medium{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for media")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Medium records from the query.
+func (q mediumQuery) All(ctx context.Context, exec boil.ContextExecutor) (MediumSlice, error) {
+	var o []*Medium
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Medium slice")
+	}
+
+	if len(mediumAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Medium records in the query.
+func (q mediumQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count media rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q mediumQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if media exists")
+	}
+
+	return count > 0, nil
+}
+
+// Media retrieves all the records using an executor.
+func Media(mods ...qm.QueryMod) mediumQuery {
+	mods = append(mods, qm.From("\"media\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"media\".*"})
+	}
+
+	return mediumQuery{q}
+}
+
+// FindMedium retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindMedium(ctx context.Context, exec boil.ContextExecutor, messageID string, selectCols ...string) (*Medium, error) {
+	mediumObj := &Medium{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"media\" where \"message_id\"=?", sel,
+	)
+
+	q := queries.Raw(query, messageID)
+
+	err := q.Bind(ctx, exec, mediumObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from media")
+	}
+
+	if err = mediumObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return mediumObj, err
+	}
+
+	return mediumObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Medium) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no media provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(mediumColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	mediumInsertCacheMut.RLock()
+	cache, cached := mediumInsertCache[key]
+	mediumInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			mediumAllColumns,
+			mediumColumnsWithDefault,
+			mediumColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(mediumType, mediumMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(mediumType, mediumMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"media\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"media\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into media")
+	}
+
+	if !cached {
+		mediumInsertCacheMut.Lock()
+		mediumInsertCache[key] = cache
+		mediumInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Medium.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Medium) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	mediumUpdateCacheMut.RLock()
+	cache, cached := mediumUpdateCache[key]
+	mediumUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			mediumAllColumns,
+			mediumPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update media, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"media\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, mediumPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(mediumType, mediumMapping, append(wl, mediumPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update media row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for media")
+	}
+
+	if !cached {
+		mediumUpdateCacheMut.Lock()
+		mediumUpdateCache[key] = cache
+		mediumUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q mediumQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for media")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for media")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o MediumSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), mediumPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"media\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, mediumPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in medium slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all medium")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Medium) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no media provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(mediumColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	mediumUpsertCacheMut.RLock()
+	cache, cached := mediumUpsertCache[key]
+	mediumUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			mediumAllColumns,
+			mediumColumnsWithDefault,
+			mediumColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			mediumAllColumns,
+			mediumPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert media, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(mediumAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(mediumPrimaryKeyColumns))
+			copy(conflict, mediumPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"media\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(mediumType, mediumMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(mediumType, mediumMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert media")
+	}
+
+	if !cached {
+		mediumUpsertCacheMut.Lock()
+		mediumUpsertCache[key] = cache
+		mediumUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Medium record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Medium) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Medium provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), mediumPrimaryKeyMapping)
+	sql := "DELETE FROM \"media\" WHERE \"message_id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from media")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for media")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q mediumQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no mediumQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from media")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for media")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o MediumSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(mediumBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), mediumPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"media\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, mediumPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from medium slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for media")
+	}
+
+	if len(mediumAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Medium) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindMedium(ctx, exec, o.MessageID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *MediumSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := MediumSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), mediumPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"media\".* FROM \"media\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, mediumPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in MediumSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// MediumExists checks if the Medium row exists.
+func MediumExists(ctx context.Context, exec boil.ContextExecutor, messageID string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"media\" where \"message_id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, messageID)
+	}
+	row := exec.QueryRowContext(ctx, sql, messageID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if media exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Medium row exists.
+func (o *Medium) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return MediumExists(ctx, exec, o.MessageID)
+}
@@ -24,117 +24,241 @@ import (
 
 // Message is an object representing the database table.
 type Message struct {
-	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	ChatID    int64     `boil:"chat_id" json:"chat_id" toml:"chat_id" yaml:"chat_id"`
-	FromID    int64     `boil:"from_id" json:"from_id" toml:"from_id" yaml:"from_id"`
-	MSGID     int64     `boil:"msg_id" json:"msg_id" toml:"msg_id" yaml:"msg_id"`
-	Text      string    `boil:"text" json:"text" toml:"text" yaml:"text"`
-	Timestamp time.Time `boil:"timestamp" json:"timestamp" toml:"timestamp" yaml:"timestamp"`
-	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID              string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ChatID          int64       `boil:"chat_id" json:"chat_id" toml:"chat_id" yaml:"chat_id"`
+	FromID          int64       `boil:"from_id" json:"from_id" toml:"from_id" yaml:"from_id"`
+	MSGID           int64       `boil:"msg_id" json:"msg_id" toml:"msg_id" yaml:"msg_id"`
+	Text            string      `boil:"text" json:"text" toml:"text" yaml:"text"`
+	Timestamp       time.Time   `boil:"timestamp" json:"timestamp" toml:"timestamp" yaml:"timestamp"`
+	DeletedAt       null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	HasMedia        bool        `boil:"has_media" json:"has_media" toml:"has_media" yaml:"has_media"`
+	SearchText      null.String `boil:"search_text" json:"search_text,omitempty" toml:"search_text" yaml:"search_text,omitempty"`
+	SegmentedText   null.String `boil:"segmented_text" json:"segmented_text,omitempty" toml:"segmented_text" yaml:"segmented_text,omitempty"`
+	DeletedBy       null.Int64  `boil:"deleted_by" json:"deleted_by,omitempty" toml:"deleted_by" yaml:"deleted_by,omitempty"`
+	Formatting      string      `boil:"formatting" json:"formatting" toml:"formatting" yaml:"formatting"`
+	ReplyToMSGID    null.Int64  `boil:"reply_to_msg_id" json:"reply_to_msg_id,omitempty" toml:"reply_to_msg_id" yaml:"reply_to_msg_id,omitempty"`
+	MessageThreadID null.Int64  `boil:"message_thread_id" json:"message_thread_id,omitempty" toml:"message_thread_id" yaml:"message_thread_id,omitempty"`
+	ForwardFromID   null.Int64  `boil:"forward_from_id" json:"forward_from_id,omitempty" toml:"forward_from_id" yaml:"forward_from_id,omitempty"`
+	ForwardFromName string      `boil:"forward_from_name" json:"forward_from_name" toml:"forward_from_name" yaml:"forward_from_name"`
 
 	R *messageR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L messageL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var MessageColumns = struct {
-	ID        string
-	ChatID    string
-	FromID    string
-	MSGID     string
-	Text      string
-	Timestamp string
-	DeletedAt string
+	ID              string
+	ChatID          string
+	FromID          string
+	MSGID           string
+	Text            string
+	Timestamp       string
+	DeletedAt       string
+	HasMedia        string
+	SearchText      string
+	SegmentedText   string
+	DeletedBy       string
+	Formatting      string
+	ReplyToMSGID    string
+	MessageThreadID string
+	ForwardFromID   string
+	ForwardFromName string
 }{
-	ID:        "id",
-	ChatID:    "chat_id",
-	FromID:    "from_id",
-	MSGID:     "msg_id",
-	Text:      "text",
-	Timestamp: "timestamp",
-	DeletedAt: "deleted_at",
+	ID:              "id",
+	ChatID:          "chat_id",
+	FromID:          "from_id",
+	MSGID:           "msg_id",
+	Text:            "text",
+	Timestamp:       "timestamp",
+	DeletedAt:       "deleted_at",
+	HasMedia:        "has_media",
+	SearchText:      "search_text",
+	SegmentedText:   "segmented_text",
+	DeletedBy:       "deleted_by",
+	Formatting:      "formatting",
+	ReplyToMSGID:    "reply_to_msg_id",
+	MessageThreadID: "message_thread_id",
+	ForwardFromID:   "forward_from_id",
+	ForwardFromName: "forward_from_name",
 }
 
 var MessageTableColumns = struct {
-	ID        string
-	ChatID    string
-	FromID    string
-	MSGID     string
-	Text      string
-	Timestamp string
-	DeletedAt string
+	ID              string
+	ChatID          string
+	FromID          string
+	MSGID           string
+	Text            string
+	Timestamp       string
+	DeletedAt       string
+	HasMedia        string
+	SearchText      string
+	SegmentedText   string
+	DeletedBy       string
+	Formatting      string
+	ReplyToMSGID    string
+	MessageThreadID string
+	ForwardFromID   string
+	ForwardFromName string
 }{
-	ID:        "message.id",
-	ChatID:    "message.chat_id",
-	FromID:    "message.from_id",
-	MSGID:     "message.msg_id",
-	Text:      "message.text",
-	Timestamp: "message.timestamp",
-	DeletedAt: "message.deleted_at",
+	ID:              "message.id",
+	ChatID:          "message.chat_id",
+	FromID:          "message.from_id",
+	MSGID:           "message.msg_id",
+	Text:            "message.text",
+	Timestamp:       "message.timestamp",
+	DeletedAt:       "message.deleted_at",
+	HasMedia:        "message.has_media",
+	SearchText:      "message.search_text",
+	SegmentedText:   "message.segmented_text",
+	DeletedBy:       "message.deleted_by",
+	Formatting:      "message.formatting",
+	ReplyToMSGID:    "message.reply_to_msg_id",
+	MessageThreadID: "message.message_thread_id",
+	ForwardFromID:   "message.forward_from_id",
+	ForwardFromName: "message.forward_from_name",
 }
 
 // Generated where
 
-type whereHelpertime_Time struct{ field string }
+type whereHelpernull_Time struct{ field string }
 
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelpernull_Time struct{ field string }
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" LIKE ?", x)
+}
+func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT LIKE ?", x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
 
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var MessageWhere = struct {
-	ID        whereHelperstring
-	ChatID    whereHelperint64
-	FromID    whereHelperint64
-	MSGID     whereHelperint64
-	Text      whereHelperstring
-	Timestamp whereHelpertime_Time
-	DeletedAt whereHelpernull_Time
+	ID              whereHelperstring
+	ChatID          whereHelperint64
+	FromID          whereHelperint64
+	MSGID           whereHelperint64
+	Text            whereHelperstring
+	Timestamp       whereHelpertime_Time
+	DeletedAt       whereHelpernull_Time
+	HasMedia        whereHelperbool
+	SearchText      whereHelpernull_String
+	SegmentedText   whereHelpernull_String
+	DeletedBy       whereHelpernull_Int64
+	Formatting      whereHelperstring
+	ReplyToMSGID    whereHelpernull_Int64
+	MessageThreadID whereHelpernull_Int64
+	ForwardFromID   whereHelpernull_Int64
+	ForwardFromName whereHelperstring
 }{
-	ID:        whereHelperstring{field: "\"message\".\"id\""},
-	ChatID:    whereHelperint64{field: "\"message\".\"chat_id\""},
-	FromID:    whereHelperint64{field: "\"message\".\"from_id\""},
-	MSGID:     whereHelperint64{field: "\"message\".\"msg_id\""},
-	Text:      whereHelperstring{field: "\"message\".\"text\""},
-	Timestamp: whereHelpertime_Time{field: "\"message\".\"timestamp\""},
-	DeletedAt: whereHelpernull_Time{field: "\"message\".\"deleted_at\""},
+	ID:              whereHelperstring{field: "\"message\".\"id\""},
+	ChatID:          whereHelperint64{field: "\"message\".\"chat_id\""},
+	FromID:          whereHelperint64{field: "\"message\".\"from_id\""},
+	MSGID:           whereHelperint64{field: "\"message\".\"msg_id\""},
+	Text:            whereHelperstring{field: "\"message\".\"text\""},
+	Timestamp:       whereHelpertime_Time{field: "\"message\".\"timestamp\""},
+	DeletedAt:       whereHelpernull_Time{field: "\"message\".\"deleted_at\""},
+	HasMedia:        whereHelperbool{field: "\"message\".\"has_media\""},
+	SearchText:      whereHelpernull_String{field: "\"message\".\"search_text\""},
+	SegmentedText:   whereHelpernull_String{field: "\"message\".\"segmented_text\""},
+	DeletedBy:       whereHelpernull_Int64{field: "\"message\".\"deleted_by\""},
+	Formatting:      whereHelperstring{field: "\"message\".\"formatting\""},
+	ReplyToMSGID:    whereHelpernull_Int64{field: "\"message\".\"reply_to_msg_id\""},
+	MessageThreadID: whereHelpernull_Int64{field: "\"message\".\"message_thread_id\""},
+	ForwardFromID:   whereHelpernull_Int64{field: "\"message\".\"forward_from_id\""},
+	ForwardFromName: whereHelperstring{field: "\"message\".\"forward_from_name\""},
 }
 
 // MessageRels is where relationship names are stored.
@@ -154,9 +278,9 @@ func (*messageR) NewStruct() *messageR {
 type messageL struct{}
 
 var (
-	messageAllColumns            = []string{"id", "chat_id", "from_id", "msg_id", "text", "timestamp", "deleted_at"}
+	messageAllColumns            = []string{"id", "chat_id", "from_id", "msg_id", "text", "timestamp", "deleted_at", "has_media", "search_text", "segmented_text", "deleted_by", "formatting", "reply_to_msg_id", "message_thread_id", "forward_from_id", "forward_from_name"}
 	messageColumnsWithoutDefault = []string{"id", "chat_id", "from_id", "msg_id", "text", "timestamp"}
-	messageColumnsWithDefault    = []string{"deleted_at"}
+	messageColumnsWithDefault    = []string{"deleted_at", "has_media", "search_text", "segmented_text", "deleted_by", "formatting", "reply_to_msg_id", "message_thread_id", "forward_from_id", "forward_from_name"}
 	messagePrimaryKeyColumns     = []string{"id"}
 	messageGeneratedColumns      = []string{}
 )
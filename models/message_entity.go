@@ -0,0 +1,946 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// MessageEntity is an object representing the database table.
+type MessageEntity struct {
+	ID        int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	MessageID string `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
+	Type      string `boil:"type" json:"type" toml:"type" yaml:"type"`
+	Value     string `boil:"value" json:"value" toml:"value" yaml:"value"`
+	Domain    string `boil:"domain" json:"domain" toml:"domain" yaml:"domain"`
+
+	R *messageEntityR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L messageEntityL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var MessageEntityColumns = struct {
+	ID        string
+	MessageID string
+	Type      string
+	Value     string
+	Domain    string
+}{
+	ID:        "id",
+	MessageID: "message_id",
+	Type:      "type",
+	Value:     "value",
+	Domain:    "domain",
+}
+
+var MessageEntityTableColumns = struct {
+	ID        string
+	MessageID string
+	Type      string
+	Value     string
+	Domain    string
+}{
+	ID:        "message_entity.id",
+	MessageID: "message_entity.message_id",
+	Type:      "message_entity.type",
+	Value:     "message_entity.value",
+	Domain:    "message_entity.domain",
+}
+
+// Generated where
+
+var MessageEntityWhere = struct {
+	ID        whereHelperint64
+	MessageID whereHelperstring
+	Type      whereHelperstring
+	Value     whereHelperstring
+	Domain    whereHelperstring
+}{
+	ID:        whereHelperint64{field: "\"message_entity\".\"id\""},
+	MessageID: whereHelperstring{field: "\"message_entity\".\"message_id\""},
+	Type:      whereHelperstring{field: "\"message_entity\".\"type\""},
+	Value:     whereHelperstring{field: "\"message_entity\".\"value\""},
+	Domain:    whereHelperstring{field: "\"message_entity\".\"domain\""},
+}
+
+// MessageEntityRels is where relationship names are stored.
+var MessageEntityRels = struct {
+}{}
+
+// messageEntityR is where relationships are stored.
+type messageEntityR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*messageEntityR) NewStruct() *messageEntityR {
+	return &messageEntityR{}
+}
+
+// messageEntityL is where Load methods for each relationship are stored.
+type messageEntityL struct{}
+
+var (
+	messageEntityAllColumns            = []string{"id", "message_id", "type", "value", "domain"}
+	messageEntityColumnsWithoutDefault = []string{"message_id", "type", "value"}
+	messageEntityColumnsWithDefault    = []string{"id", "domain"}
+	messageEntityPrimaryKeyColumns     = []string{"id"}
+	messageEntityGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// MessageEntitySlice is an alias for a slice of pointers to MessageEntity.
+	// This should almost always be used instead of []MessageEntity.
+	MessageEntitySlice []*MessageEntity
+	// MessageEntityHook is the signature for custom MessageEntity hook methods
+	MessageEntityHook func(context.Context, boil.ContextExecutor, *MessageEntity) error
+
+	messageEntityQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	messageEntityType                 = reflect.TypeOf(&MessageEntity{})
+	messageEntityMapping              = queries.MakeStructMapping(messageEntityType)
+	messageEntityPrimaryKeyMapping, _ = queries.BindMapping(messageEntityType, messageEntityMapping, messageEntityPrimaryKeyColumns)
+	messageEntityInsertCacheMut       sync.RWMutex
+	messageEntityInsertCache          = make(map[string]insertCache)
+	messageEntityUpdateCacheMut       sync.RWMutex
+	messageEntityUpdateCache          = make(map[string]updateCache)
+	messageEntityUpsertCacheMut       sync.RWMutex
+	messageEntityUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var messageEntityAfterSelectMu sync.Mutex
+var messageEntityAfterSelectHooks []MessageEntityHook
+
+var messageEntityBeforeInsertMu sync.Mutex
+var messageEntityBeforeInsertHooks []MessageEntityHook
+var messageEntityAfterInsertMu sync.Mutex
+var messageEntityAfterInsertHooks []MessageEntityHook
+
+var messageEntityBeforeUpdateMu sync.Mutex
+var messageEntityBeforeUpdateHooks []MessageEntityHook
+var messageEntityAfterUpdateMu sync.Mutex
+var messageEntityAfterUpdateHooks []MessageEntityHook
+
+var messageEntityBeforeDeleteMu sync.Mutex
+var messageEntityBeforeDeleteHooks []MessageEntityHook
+var messageEntityAfterDeleteMu sync.Mutex
+var messageEntityAfterDeleteHooks []MessageEntityHook
+
+var messageEntityBeforeUpsertMu sync.Mutex
+var messageEntityBeforeUpsertHooks []MessageEntityHook
+var messageEntityAfterUpsertMu sync.Mutex
+var messageEntityAfterUpsertHooks []MessageEntityHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *MessageEntity) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *MessageEntity) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *MessageEntity) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *MessageEntity) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *MessageEntity) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *MessageEntity) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *MessageEntity) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *MessageEntity) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *MessageEntity) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageEntityAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddMessageEntityHook registers your hook function for all future operations.
+func AddMessageEntityHook(hookPoint boil.HookPoint, messageEntityHook MessageEntityHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		messageEntityAfterSelectMu.Lock()
+		messageEntityAfterSelectHooks = append(messageEntityAfterSelectHooks, messageEntityHook)
+		messageEntityAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		messageEntityBeforeInsertMu.Lock()
+		messageEntityBeforeInsertHooks = append(messageEntityBeforeInsertHooks, messageEntityHook)
+		messageEntityBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		messageEntityAfterInsertMu.Lock()
+		messageEntityAfterInsertHooks = append(messageEntityAfterInsertHooks, messageEntityHook)
+		messageEntityAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		messageEntityBeforeUpdateMu.Lock()
+		messageEntityBeforeUpdateHooks = append(messageEntityBeforeUpdateHooks, messageEntityHook)
+		messageEntityBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		messageEntityAfterUpdateMu.Lock()
+		messageEntityAfterUpdateHooks = append(messageEntityAfterUpdateHooks, messageEntityHook)
+		messageEntityAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		messageEntityBeforeDeleteMu.Lock()
+		messageEntityBeforeDeleteHooks = append(messageEntityBeforeDeleteHooks, messageEntityHook)
+		messageEntityBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		messageEntityAfterDeleteMu.Lock()
+		messageEntityAfterDeleteHooks = append(messageEntityAfterDeleteHooks, messageEntityHook)
+		messageEntityAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		messageEntityBeforeUpsertMu.Lock()
+		messageEntityBeforeUpsertHooks = append(messageEntityBeforeUpsertHooks, messageEntityHook)
+		messageEntityBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		messageEntityAfterUpsertMu.Lock()
+		messageEntityAfterUpsertHooks = append(messageEntityAfterUpsertHooks, messageEntityHook)
+		messageEntityAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single messageEntity record from the query.
+func (q messageEntityQuery) One(ctx context.Context, exec boil.ContextExecutor) (*MessageEntity, error) {
+	o := &MessageEntity{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for message_entity")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all MessageEntity records from the query.
+func (q messageEntityQuery) All(ctx context.Context, exec boil.ContextExecutor) (MessageEntitySlice, error) {
+	var o []*MessageEntity
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to MessageEntity slice")
+	}
+
+	if len(messageEntityAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all MessageEntity records in the query.
+func (q messageEntityQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count message_entity rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q messageEntityQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if message_entity exists")
+	}
+
+	return count > 0, nil
+}
+
+// MessageEntities retrieves all the records using an executor.
+func MessageEntities(mods ...qm.QueryMod) messageEntityQuery {
+	mods = append(mods, qm.From("\"message_entity\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"message_entity\".*"})
+	}
+
+	return messageEntityQuery{q}
+}
+
+// FindMessageEntity retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindMessageEntity(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*MessageEntity, error) {
+	messageEntityObj := &MessageEntity{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"message_entity\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, messageEntityObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from message_entity")
+	}
+
+	if err = messageEntityObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return messageEntityObj, err
+	}
+
+	return messageEntityObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *MessageEntity) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no message_entity provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(messageEntityColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	messageEntityInsertCacheMut.RLock()
+	cache, cached := messageEntityInsertCache[key]
+	messageEntityInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			messageEntityAllColumns,
+			messageEntityColumnsWithDefault,
+			messageEntityColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, messageEntityGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(messageEntityType, messageEntityMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(messageEntityType, messageEntityMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"message_entity\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"message_entity\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into message_entity")
+	}
+
+	if !cached {
+		messageEntityInsertCacheMut.Lock()
+		messageEntityInsertCache[key] = cache
+		messageEntityInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the MessageEntity.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *MessageEntity) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	messageEntityUpdateCacheMut.RLock()
+	cache, cached := messageEntityUpdateCache[key]
+	messageEntityUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			messageEntityAllColumns,
+			messageEntityPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, messageEntityGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update message_entity, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"message_entity\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, messageEntityPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(messageEntityType, messageEntityMapping, append(wl, messageEntityPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update message_entity row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for message_entity")
+	}
+
+	if !cached {
+		messageEntityUpdateCacheMut.Lock()
+		messageEntityUpdateCache[key] = cache
+		messageEntityUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q messageEntityQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for message_entity")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for message_entity")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o MessageEntitySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), messageEntityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"message_entity\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, messageEntityPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in messageEntity slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all messageEntity")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *MessageEntity) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no message_entity provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(messageEntityColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	messageEntityUpsertCacheMut.RLock()
+	cache, cached := messageEntityUpsertCache[key]
+	messageEntityUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			messageEntityAllColumns,
+			messageEntityColumnsWithDefault,
+			messageEntityColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			messageEntityAllColumns,
+			messageEntityPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert message_entity, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(messageEntityAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(messageEntityPrimaryKeyColumns))
+			copy(conflict, messageEntityPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"message_entity\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(messageEntityType, messageEntityMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(messageEntityType, messageEntityMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert message_entity")
+	}
+
+	if !cached {
+		messageEntityUpsertCacheMut.Lock()
+		messageEntityUpsertCache[key] = cache
+		messageEntityUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single MessageEntity record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *MessageEntity) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no MessageEntity provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), messageEntityPrimaryKeyMapping)
+	sql := "DELETE FROM \"message_entity\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from message_entity")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for message_entity")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q messageEntityQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no messageEntityQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from message_entity")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for message_entity")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o MessageEntitySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(messageEntityBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), messageEntityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"message_entity\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, messageEntityPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from messageEntity slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for message_entity")
+	}
+
+	if len(messageEntityAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *MessageEntity) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindMessageEntity(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *MessageEntitySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := MessageEntitySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), messageEntityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"message_entity\".* FROM \"message_entity\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, messageEntityPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in MessageEntitySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// MessageEntityExists checks if the MessageEntity row exists.
+func MessageEntityExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"message_entity\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if message_entity exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the MessageEntity row exists.
+func (o *MessageEntity) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return MessageEntityExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testMessageEntities(t *testing.T) {
+	t.Parallel()
+
+	query := MessageEntities()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testMessageEntitiesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMessageEntitiesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := MessageEntities().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMessageEntitiesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := MessageEntitySlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMessageEntitiesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := MessageEntityExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if MessageEntity exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected MessageEntityExists to return true, but got false.")
+	}
+}
+
+func testMessageEntitiesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	messageEntityFound, err := FindMessageEntity(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if messageEntityFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testMessageEntitiesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = MessageEntities().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMessageEntitiesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := MessageEntities().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testMessageEntitiesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	messageEntityOne := &MessageEntity{}
+	messageEntityTwo := &MessageEntity{}
+	if err = randomize.Struct(seed, messageEntityOne, messageEntityDBTypes, false, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+	if err = randomize.Struct(seed, messageEntityTwo, messageEntityDBTypes, false, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = messageEntityOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = messageEntityTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := MessageEntities().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testMessageEntitiesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	messageEntityOne := &MessageEntity{}
+	messageEntityTwo := &MessageEntity{}
+	if err = randomize.Struct(seed, messageEntityOne, messageEntityDBTypes, false, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+	if err = randomize.Struct(seed, messageEntityTwo, messageEntityDBTypes, false, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = messageEntityOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = messageEntityTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func messageEntityBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func messageEntityAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageEntity) error {
+	*o = MessageEntity{}
+	return nil
+}
+
+func testMessageEntitiesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &MessageEntity{}
+	o := &MessageEntity{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize MessageEntity object: %s", err)
+	}
+
+	AddMessageEntityHook(boil.BeforeInsertHook, messageEntityBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	messageEntityBeforeInsertHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.AfterInsertHook, messageEntityAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	messageEntityAfterInsertHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.AfterSelectHook, messageEntityAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	messageEntityAfterSelectHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.BeforeUpdateHook, messageEntityBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	messageEntityBeforeUpdateHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.AfterUpdateHook, messageEntityAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	messageEntityAfterUpdateHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.BeforeDeleteHook, messageEntityBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	messageEntityBeforeDeleteHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.AfterDeleteHook, messageEntityAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	messageEntityAfterDeleteHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.BeforeUpsertHook, messageEntityBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	messageEntityBeforeUpsertHooks = []MessageEntityHook{}
+
+	AddMessageEntityHook(boil.AfterUpsertHook, messageEntityAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	messageEntityAfterUpsertHooks = []MessageEntityHook{}
+}
+
+func testMessageEntitiesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testMessageEntitiesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(messageEntityColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testMessageEntitiesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMessageEntitiesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := MessageEntitySlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMessageEntitiesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := MessageEntities().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	messageEntityDBTypes = map[string]string{`ID`: `INTEGER`, `MessageID`: `TEXT`, `Type`: `TEXT`, `Value`: `TEXT`, `Domain`: `TEXT`}
+	_                    = bytes.MinRead
+)
+
+func testMessageEntitiesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(messageEntityPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(messageEntityAllColumns) == len(messageEntityPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testMessageEntitiesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(messageEntityAllColumns) == len(messageEntityPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageEntity{}
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, messageEntityDBTypes, true, messageEntityPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(messageEntityAllColumns, messageEntityPrimaryKeyColumns) {
+		fields = messageEntityAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			messageEntityAllColumns,
+			messageEntityPrimaryKeyColumns,
+		)
+		fields = strmangle.SetComplement(fields, messageEntityGeneratedColumns)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := MessageEntitySlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testMessageEntitiesUpsert(t *testing.T) {
+	t.Parallel()
+	if len(messageEntityAllColumns) == len(messageEntityPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := MessageEntity{}
+	if err = randomize.Struct(seed, &o, messageEntityDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert MessageEntity: %s", err)
+	}
+
+	count, err := MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, messageEntityDBTypes, false, messageEntityPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MessageEntity struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert MessageEntity: %s", err)
+	}
+
+	count, err = MessageEntities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,946 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// MessageRevision is an object representing the database table.
+type MessageRevision struct {
+	ID         int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	MessageID  string    `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
+	Text       string    `boil:"text" json:"text" toml:"text" yaml:"text"`
+	SearchText string    `boil:"search_text" json:"search_text" toml:"search_text" yaml:"search_text"`
+	EditDate   time.Time `boil:"edit_date" json:"edit_date" toml:"edit_date" yaml:"edit_date"`
+
+	R *messageRevisionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L messageRevisionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var MessageRevisionColumns = struct {
+	ID         string
+	MessageID  string
+	Text       string
+	SearchText string
+	EditDate   string
+}{
+	ID:         "id",
+	MessageID:  "message_id",
+	Text:       "text",
+	SearchText: "search_text",
+	EditDate:   "edit_date",
+}
+
+var MessageRevisionTableColumns = struct {
+	ID         string
+	MessageID  string
+	Text       string
+	SearchText string
+	EditDate   string
+}{
+	ID:         "message_revision.id",
+	MessageID:  "message_revision.message_id",
+	Text:       "message_revision.text",
+	SearchText: "message_revision.search_text",
+	EditDate:   "message_revision.edit_date",
+}
+
+// Generated where
+
+var MessageRevisionWhere = struct {
+	ID         whereHelperint64
+	MessageID  whereHelperstring
+	Text       whereHelperstring
+	SearchText whereHelperstring
+	EditDate   whereHelpertime_Time
+}{
+	ID:         whereHelperint64{field: "\"message_revision\".\"id\""},
+	MessageID:  whereHelperstring{field: "\"message_revision\".\"message_id\""},
+	Text:       whereHelperstring{field: "\"message_revision\".\"text\""},
+	SearchText: whereHelperstring{field: "\"message_revision\".\"search_text\""},
+	EditDate:   whereHelpertime_Time{field: "\"message_revision\".\"edit_date\""},
+}
+
+// MessageRevisionRels is where relationship names are stored.
+var MessageRevisionRels = struct {
+}{}
+
+// messageRevisionR is where relationships are stored.
+type messageRevisionR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*messageRevisionR) NewStruct() *messageRevisionR {
+	return &messageRevisionR{}
+}
+
+// messageRevisionL is where Load methods for each relationship are stored.
+type messageRevisionL struct{}
+
+var (
+	messageRevisionAllColumns            = []string{"id", "message_id", "text", "search_text", "edit_date"}
+	messageRevisionColumnsWithoutDefault = []string{"message_id", "text", "search_text", "edit_date"}
+	messageRevisionColumnsWithDefault    = []string{"id"}
+	messageRevisionPrimaryKeyColumns     = []string{"id"}
+	messageRevisionGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// MessageRevisionSlice is an alias for a slice of pointers to MessageRevision.
+	// This should almost always be used instead of []MessageRevision.
+	MessageRevisionSlice []*MessageRevision
+	// MessageRevisionHook is the signature for custom MessageRevision hook methods
+	MessageRevisionHook func(context.Context, boil.ContextExecutor, *MessageRevision) error
+
+	messageRevisionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	messageRevisionType                 = reflect.TypeOf(&MessageRevision{})
+	messageRevisionMapping              = queries.MakeStructMapping(messageRevisionType)
+	messageRevisionPrimaryKeyMapping, _ = queries.BindMapping(messageRevisionType, messageRevisionMapping, messageRevisionPrimaryKeyColumns)
+	messageRevisionInsertCacheMut       sync.RWMutex
+	messageRevisionInsertCache          = make(map[string]insertCache)
+	messageRevisionUpdateCacheMut       sync.RWMutex
+	messageRevisionUpdateCache          = make(map[string]updateCache)
+	messageRevisionUpsertCacheMut       sync.RWMutex
+	messageRevisionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var messageRevisionAfterSelectMu sync.Mutex
+var messageRevisionAfterSelectHooks []MessageRevisionHook
+
+var messageRevisionBeforeInsertMu sync.Mutex
+var messageRevisionBeforeInsertHooks []MessageRevisionHook
+var messageRevisionAfterInsertMu sync.Mutex
+var messageRevisionAfterInsertHooks []MessageRevisionHook
+
+var messageRevisionBeforeUpdateMu sync.Mutex
+var messageRevisionBeforeUpdateHooks []MessageRevisionHook
+var messageRevisionAfterUpdateMu sync.Mutex
+var messageRevisionAfterUpdateHooks []MessageRevisionHook
+
+var messageRevisionBeforeDeleteMu sync.Mutex
+var messageRevisionBeforeDeleteHooks []MessageRevisionHook
+var messageRevisionAfterDeleteMu sync.Mutex
+var messageRevisionAfterDeleteHooks []MessageRevisionHook
+
+var messageRevisionBeforeUpsertMu sync.Mutex
+var messageRevisionBeforeUpsertHooks []MessageRevisionHook
+var messageRevisionAfterUpsertMu sync.Mutex
+var messageRevisionAfterUpsertHooks []MessageRevisionHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *MessageRevision) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *MessageRevision) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *MessageRevision) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *MessageRevision) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *MessageRevision) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *MessageRevision) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *MessageRevision) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *MessageRevision) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *MessageRevision) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range messageRevisionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddMessageRevisionHook registers your hook function for all future operations.
+func AddMessageRevisionHook(hookPoint boil.HookPoint, messageRevisionHook MessageRevisionHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		messageRevisionAfterSelectMu.Lock()
+		messageRevisionAfterSelectHooks = append(messageRevisionAfterSelectHooks, messageRevisionHook)
+		messageRevisionAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		messageRevisionBeforeInsertMu.Lock()
+		messageRevisionBeforeInsertHooks = append(messageRevisionBeforeInsertHooks, messageRevisionHook)
+		messageRevisionBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		messageRevisionAfterInsertMu.Lock()
+		messageRevisionAfterInsertHooks = append(messageRevisionAfterInsertHooks, messageRevisionHook)
+		messageRevisionAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		messageRevisionBeforeUpdateMu.Lock()
+		messageRevisionBeforeUpdateHooks = append(messageRevisionBeforeUpdateHooks, messageRevisionHook)
+		messageRevisionBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		messageRevisionAfterUpdateMu.Lock()
+		messageRevisionAfterUpdateHooks = append(messageRevisionAfterUpdateHooks, messageRevisionHook)
+		messageRevisionAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		messageRevisionBeforeDeleteMu.Lock()
+		messageRevisionBeforeDeleteHooks = append(messageRevisionBeforeDeleteHooks, messageRevisionHook)
+		messageRevisionBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		messageRevisionAfterDeleteMu.Lock()
+		messageRevisionAfterDeleteHooks = append(messageRevisionAfterDeleteHooks, messageRevisionHook)
+		messageRevisionAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		messageRevisionBeforeUpsertMu.Lock()
+		messageRevisionBeforeUpsertHooks = append(messageRevisionBeforeUpsertHooks, messageRevisionHook)
+		messageRevisionBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		messageRevisionAfterUpsertMu.Lock()
+		messageRevisionAfterUpsertHooks = append(messageRevisionAfterUpsertHooks, messageRevisionHook)
+		messageRevisionAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single messageRevision record from the query.
+func (q messageRevisionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*MessageRevision, error) {
+	o := &MessageRevision{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for message_revision")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all MessageRevision records from the query.
+func (q messageRevisionQuery) All(ctx context.Context, exec boil.ContextExecutor) (MessageRevisionSlice, error) {
+	var o []*MessageRevision
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to MessageRevision slice")
+	}
+
+	if len(messageRevisionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all MessageRevision records in the query.
+func (q messageRevisionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count message_revision rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q messageRevisionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if message_revision exists")
+	}
+
+	return count > 0, nil
+}
+
+// MessageRevisions retrieves all the records using an executor.
+func MessageRevisions(mods ...qm.QueryMod) messageRevisionQuery {
+	mods = append(mods, qm.From("\"message_revision\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"message_revision\".*"})
+	}
+
+	return messageRevisionQuery{q}
+}
+
+// FindMessageRevision retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindMessageRevision(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*MessageRevision, error) {
+	messageRevisionObj := &MessageRevision{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"message_revision\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, messageRevisionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from message_revision")
+	}
+
+	if err = messageRevisionObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return messageRevisionObj, err
+	}
+
+	return messageRevisionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *MessageRevision) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no message_revision provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(messageRevisionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	messageRevisionInsertCacheMut.RLock()
+	cache, cached := messageRevisionInsertCache[key]
+	messageRevisionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			messageRevisionAllColumns,
+			messageRevisionColumnsWithDefault,
+			messageRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, messageRevisionGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(messageRevisionType, messageRevisionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(messageRevisionType, messageRevisionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"message_revision\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"message_revision\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into message_revision")
+	}
+
+	if !cached {
+		messageRevisionInsertCacheMut.Lock()
+		messageRevisionInsertCache[key] = cache
+		messageRevisionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the MessageRevision.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *MessageRevision) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	messageRevisionUpdateCacheMut.RLock()
+	cache, cached := messageRevisionUpdateCache[key]
+	messageRevisionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			messageRevisionAllColumns,
+			messageRevisionPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, messageRevisionGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update message_revision, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"message_revision\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, messageRevisionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(messageRevisionType, messageRevisionMapping, append(wl, messageRevisionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update message_revision row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for message_revision")
+	}
+
+	if !cached {
+		messageRevisionUpdateCacheMut.Lock()
+		messageRevisionUpdateCache[key] = cache
+		messageRevisionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q messageRevisionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for message_revision")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for message_revision")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o MessageRevisionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), messageRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"message_revision\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, messageRevisionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in messageRevision slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all messageRevision")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *MessageRevision) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no message_revision provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(messageRevisionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	messageRevisionUpsertCacheMut.RLock()
+	cache, cached := messageRevisionUpsertCache[key]
+	messageRevisionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			messageRevisionAllColumns,
+			messageRevisionColumnsWithDefault,
+			messageRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			messageRevisionAllColumns,
+			messageRevisionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert message_revision, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(messageRevisionAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(messageRevisionPrimaryKeyColumns))
+			copy(conflict, messageRevisionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"message_revision\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(messageRevisionType, messageRevisionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(messageRevisionType, messageRevisionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert message_revision")
+	}
+
+	if !cached {
+		messageRevisionUpsertCacheMut.Lock()
+		messageRevisionUpsertCache[key] = cache
+		messageRevisionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single MessageRevision record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *MessageRevision) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no MessageRevision provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), messageRevisionPrimaryKeyMapping)
+	sql := "DELETE FROM \"message_revision\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from message_revision")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for message_revision")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q messageRevisionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no messageRevisionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from message_revision")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for message_revision")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o MessageRevisionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(messageRevisionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), messageRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"message_revision\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, messageRevisionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from messageRevision slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for message_revision")
+	}
+
+	if len(messageRevisionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *MessageRevision) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindMessageRevision(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *MessageRevisionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := MessageRevisionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), messageRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"message_revision\".* FROM \"message_revision\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, messageRevisionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in MessageRevisionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// MessageRevisionExists checks if the MessageRevision row exists.
+func MessageRevisionExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"message_revision\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if message_revision exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the MessageRevision row exists.
+func (o *MessageRevision) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return MessageRevisionExists(ctx, exec, o.ID)
+}
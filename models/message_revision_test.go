@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testMessageRevisions(t *testing.T) {
+	t.Parallel()
+
+	query := MessageRevisions()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testMessageRevisionsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMessageRevisionsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := MessageRevisions().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMessageRevisionsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := MessageRevisionSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMessageRevisionsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := MessageRevisionExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if MessageRevision exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected MessageRevisionExists to return true, but got false.")
+	}
+}
+
+func testMessageRevisionsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	messageRevisionFound, err := FindMessageRevision(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if messageRevisionFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testMessageRevisionsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = MessageRevisions().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMessageRevisionsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := MessageRevisions().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testMessageRevisionsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	messageRevisionOne := &MessageRevision{}
+	messageRevisionTwo := &MessageRevision{}
+	if err = randomize.Struct(seed, messageRevisionOne, messageRevisionDBTypes, false, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+	if err = randomize.Struct(seed, messageRevisionTwo, messageRevisionDBTypes, false, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = messageRevisionOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = messageRevisionTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := MessageRevisions().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testMessageRevisionsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	messageRevisionOne := &MessageRevision{}
+	messageRevisionTwo := &MessageRevision{}
+	if err = randomize.Struct(seed, messageRevisionOne, messageRevisionDBTypes, false, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+	if err = randomize.Struct(seed, messageRevisionTwo, messageRevisionDBTypes, false, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = messageRevisionOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = messageRevisionTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func messageRevisionBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func messageRevisionAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *MessageRevision) error {
+	*o = MessageRevision{}
+	return nil
+}
+
+func testMessageRevisionsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &MessageRevision{}
+	o := &MessageRevision{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize MessageRevision object: %s", err)
+	}
+
+	AddMessageRevisionHook(boil.BeforeInsertHook, messageRevisionBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionBeforeInsertHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.AfterInsertHook, messageRevisionAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionAfterInsertHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.AfterSelectHook, messageRevisionAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionAfterSelectHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.BeforeUpdateHook, messageRevisionBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionBeforeUpdateHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.AfterUpdateHook, messageRevisionAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionAfterUpdateHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.BeforeDeleteHook, messageRevisionBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionBeforeDeleteHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.AfterDeleteHook, messageRevisionAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionAfterDeleteHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.BeforeUpsertHook, messageRevisionBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionBeforeUpsertHooks = []MessageRevisionHook{}
+
+	AddMessageRevisionHook(boil.AfterUpsertHook, messageRevisionAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	messageRevisionAfterUpsertHooks = []MessageRevisionHook{}
+}
+
+func testMessageRevisionsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testMessageRevisionsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(messageRevisionColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testMessageRevisionsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMessageRevisionsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := MessageRevisionSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMessageRevisionsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := MessageRevisions().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	messageRevisionDBTypes = map[string]string{`ID`: `INTEGER`, `MessageID`: `TEXT`, `Text`: `TEXT`, `SearchText`: `TEXT`, `EditDate`: `DATETIME`}
+	_                      = bytes.MinRead
+)
+
+func testMessageRevisionsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(messageRevisionPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(messageRevisionAllColumns) == len(messageRevisionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testMessageRevisionsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(messageRevisionAllColumns) == len(messageRevisionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MessageRevision{}
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, messageRevisionDBTypes, true, messageRevisionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(messageRevisionAllColumns, messageRevisionPrimaryKeyColumns) {
+		fields = messageRevisionAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			messageRevisionAllColumns,
+			messageRevisionPrimaryKeyColumns,
+		)
+		fields = strmangle.SetComplement(fields, messageRevisionGeneratedColumns)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := MessageRevisionSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testMessageRevisionsUpsert(t *testing.T) {
+	t.Parallel()
+	if len(messageRevisionAllColumns) == len(messageRevisionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := MessageRevision{}
+	if err = randomize.Struct(seed, &o, messageRevisionDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert MessageRevision: %s", err)
+	}
+
+	count, err := MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, messageRevisionDBTypes, false, messageRevisionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MessageRevision struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert MessageRevision: %s", err)
+	}
+
+	count, err = MessageRevisions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
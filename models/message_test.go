@@ -669,7 +669,7 @@ func testMessagesSelect(t *testing.T) {
 }
 
 var (
-	messageDBTypes = map[string]string{`ID`: `TEXT`, `ChatID`: `INTEGER`, `FromID`: `INTEGER`, `MSGID`: `INTEGER`, `Text`: `TEXT`, `Timestamp`: `DATETIME`, `DeletedAt`: `DATETIME`}
+	messageDBTypes = map[string]string{`ID`: `TEXT`, `ChatID`: `INTEGER`, `FromID`: `INTEGER`, `MSGID`: `INTEGER`, `Text`: `TEXT`, `Timestamp`: `DATETIME`, `DeletedAt`: `DATETIME`, `HasMedia`: `BOOLEAN`, `SearchText`: `TEXT`, `SegmentedText`: `TEXT`, `DeletedBy`: `INTEGER`, `Formatting`: `TEXT`, `ReplyToMSGID`: `INTEGER`, `MessageThreadID`: `INTEGER`, `ForwardFromID`: `INTEGER`, `ForwardFromName`: `TEXT`}
 	_              = bytes.MinRead
 )
 
@@ -26,6 +26,7 @@ type Peer struct {
 	ID       int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
 	FullName string `boil:"full_name" json:"full_name" toml:"full_name" yaml:"full_name"`
 	Username string `boil:"username" json:"username" toml:"username" yaml:"username"`
+	Timezone string `boil:"timezone" json:"timezone" toml:"timezone" yaml:"timezone"`
 
 	R *peerR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L peerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -35,20 +36,24 @@ var PeerColumns = struct {
 	ID       string
 	FullName string
 	Username string
+	Timezone string
 }{
 	ID:       "id",
 	FullName: "full_name",
 	Username: "username",
+	Timezone: "timezone",
 }
 
 var PeerTableColumns = struct {
 	ID       string
 	FullName string
 	Username string
+	Timezone string
 }{
 	ID:       "peer.id",
 	FullName: "peer.full_name",
 	Username: "peer.username",
+	Timezone: "peer.timezone",
 }
 
 // Generated where
@@ -57,10 +62,12 @@ var PeerWhere = struct {
 	ID       whereHelperint64
 	FullName whereHelperstring
 	Username whereHelperstring
+	Timezone whereHelperstring
 }{
 	ID:       whereHelperint64{field: "\"peer\".\"id\""},
 	FullName: whereHelperstring{field: "\"peer\".\"full_name\""},
 	Username: whereHelperstring{field: "\"peer\".\"username\""},
+	Timezone: whereHelperstring{field: "\"peer\".\"timezone\""},
 }
 
 // PeerRels is where relationship names are stored.
@@ -80,9 +87,9 @@ func (*peerR) NewStruct() *peerR {
 type peerL struct{}
 
 var (
-	peerAllColumns            = []string{"id", "full_name", "username"}
+	peerAllColumns            = []string{"id", "full_name", "username", "timezone"}
 	peerColumnsWithoutDefault = []string{"full_name", "username"}
-	peerColumnsWithDefault    = []string{"id"}
+	peerColumnsWithDefault    = []string{"id", "timezone"}
 	peerPrimaryKeyColumns     = []string{"id"}
 	peerGeneratedColumns      = []string{"id"}
 )
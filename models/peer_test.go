@@ -568,7 +568,7 @@ func testPeersSelect(t *testing.T) {
 }
 
 var (
-	peerDBTypes = map[string]string{`ID`: `INTEGER`, `FullName`: `TEXT`, `Username`: `TEXT`}
+	peerDBTypes = map[string]string{`ID`: `INTEGER`, `FullName`: `TEXT`, `Username`: `TEXT`, `Timezone`: `TEXT`}
 	_           = bytes.MinRead
 )
 
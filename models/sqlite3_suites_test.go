@@ -10,7 +10,17 @@ func TestUpsert(t *testing.T) {
 
 	t.Run("ChatPeers", testChatPeersUpsert)
 
+	t.Run("ForumTopics", testForumTopicsUpsert)
+
+	t.Run("ImportCheckpoints", testImportCheckpointsUpsert)
+
+	t.Run("Media", testMediaUpsert)
+
 	t.Run("Messages", testMessagesUpsert)
 
+	t.Run("MessageEntities", testMessageEntitiesUpsert)
+
+	t.Run("MessageRevisions", testMessageRevisionsUpsert)
+
 	t.Run("Peers", testPeersUpsert)
 }
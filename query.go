@@ -0,0 +1,496 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// QueryNode is a node of a parsed search query
+type QueryNode interface {
+	queryNode()
+}
+
+// TermNode matches messages containing the text, Phrase is set for quoted text
+type TermNode struct {
+	Text   string
+	Phrase bool
+}
+
+// NotNode excludes messages matched by Node
+type NotNode struct {
+	Node QueryNode
+}
+
+// AndNode matches messages matched by all of Nodes
+type AndNode struct {
+	Nodes []QueryNode
+}
+
+// OrNode matches messages matched by any of Nodes
+type OrNode struct {
+	Nodes []QueryNode
+}
+
+// FromNode matches messages sent by a peer id or username
+type FromNode struct {
+	PeerId   int64
+	Username string
+}
+
+// InNode matches messages of a chat by id, username or title prefix,
+// a bare value matches both the title prefix and the username
+type InNode struct {
+	ChatId   int64
+	Username string
+	Title    string
+}
+
+// DateNode matches messages sent before or after Time
+type DateNode struct {
+	Before bool
+	Time   time.Time
+}
+
+// HasNode matches messages containing a link, any media or a kind of media
+type HasNode struct {
+	Kind string
+}
+
+// FileNameNode matches messages with a media file name containing the text
+type FileNameNode struct {
+	Text string
+}
+
+// HashtagNode matches messages tagged with #Tag
+type HashtagNode struct {
+	Tag string
+}
+
+// MentionNode matches messages mentioning a peer id or username
+type MentionNode struct {
+	PeerId   int64
+	Username string
+}
+
+// DomainNode matches messages linking to a domain or its subdomains
+type DomainNode struct {
+	Domain string
+}
+
+// ReplyToNode matches replies to a message, ChatId and ChatUsername are empty if the message id was given
+// without a link, or replies to the messages of Username if MsgId is 0
+type ReplyToNode struct {
+	ChatId       int64
+	ChatUsername string
+	MsgId        int64
+	Username     string
+}
+
+// TopicNode matches messages in forum topics with a name starting with Name or the topic ThreadId
+type TopicNode struct {
+	ThreadId int64
+	Name     string
+}
+
+func (TermNode) queryNode()     {}
+func (NotNode) queryNode()      {}
+func (AndNode) queryNode()      {}
+func (OrNode) queryNode()       {}
+func (FromNode) queryNode()     {}
+func (InNode) queryNode()       {}
+func (DateNode) queryNode()     {}
+func (HasNode) queryNode()      {}
+func (FileNameNode) queryNode() {}
+func (HashtagNode) queryNode()  {}
+func (MentionNode) queryNode()  {}
+func (DomainNode) queryNode()   {}
+func (ReplyToNode) queryNode()  {}
+func (TopicNode) queryNode()    {}
+
+const (
+	HasLink  = "link"
+	HasMedia = "media"
+	HasFile  = "file"
+)
+
+// hasKinds are the accepted values of has:, besides link, media and file they are media types
+var hasKinds = []string{HasLink, HasMedia, MediaPhoto, MediaVideo, MediaAnimation, MediaAudio, MediaVoice, MediaVideoNote, MediaSticker, HasFile}
+
+// SearchQuery is a parsed inline query
+type SearchQuery struct {
+	Root      QueryNode
+	SortOrder string
+	// Revisions also matches terms against past versions of edited messages
+	Revisions bool
+}
+
+type queryTokenKind int
+
+const (
+	tokenWord queryTokenKind = iota
+	tokenPhrase
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	// key is set for filters like from:someone
+	key string
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	loc    *time.Location
+	query  *SearchQuery
+}
+
+// ParseSearchQuery parses an inline query, dates are interpreted in loc
+//
+//	hello world           both terms
+//	"hello world"         exact phrase
+//	hello OR world        either term
+//	-hello                exclude term
+//	(hello OR hi) world   grouping
+//	from:@username        from:114514
+//	in:title prefix       in:"chat title"  in:@username  in:-100114514
+//	before:2006-01-02     after:2006-01-02 (inclusive)  on:2006-01-02
+//	has:link              has:media  has:photo  has:file  has:voice ...
+//	filename:report.pdf   filename:"annual report"
+//	#hashtag              mentions:@username  mentions:114514  domain:github.com
+//	replyto:123           replyto:https://t.me/c/1234567890/123  replyto:https://t.me/username/123
+//	replyto:@username
+//	topic:name prefix     topic:"topic name"  topic:123
+//	sort:time             sort:relevance
+//	revisions:all         also match past versions of edited messages
+//	@username text        leading sender
+func ParseSearchQuery(text string, loc *time.Location) (*SearchQuery, error) {
+	tokens, err := tokenizeQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &SearchQuery{}
+
+	if len(tokens) > 0 && tokens[0].kind == tokenWord && tokens[0].key == "" && strings.HasPrefix(tokens[0].text, "@") && len(tokens[0].text) > 1 {
+		tokens[0] = queryToken{kind: tokenWord, key: "from", text: tokens[0].text}
+	}
+
+	p := queryParser{tokens: tokens, loc: loc, query: query}
+	root, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.New("unexpected closing parenthesis")
+	}
+	if root != nil && !hasPositiveNode(root) {
+		return nil, errors.New("query can not consist of exclusions only")
+	}
+	query.Root = root
+	return query, nil
+}
+
+func tokenizeQuery(text string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && (i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '('):
+			tokens = append(tokens, queryToken{kind: tokenNot})
+			i++
+		case r == '"':
+			end := indexRune(runes, '"', i+1)
+			if end < 0 {
+				return nil, errors.New("unclosed quotation mark")
+			}
+			tokens = append(tokens, queryToken{kind: tokenPhrase, text: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			word := string(runes[start:i])
+			if word == "OR" || word == "|" {
+				tokens = append(tokens, queryToken{kind: tokenOr})
+				continue
+			}
+			key, value, found := strings.Cut(word, ":")
+			if !found || !isQueryFilter(strings.ToLower(key)) {
+				tokens = append(tokens, queryToken{kind: tokenWord, text: word})
+				continue
+			}
+			// filter values may be quoted, e.g. in:"chat title"
+			if value == "" && i < len(runes) && runes[i] == '"' {
+				end := indexRune(runes, '"', i+1)
+				if end < 0 {
+					return nil, errors.New("unclosed quotation mark")
+				}
+				value = string(runes[i+1 : end])
+				i = end + 1
+			}
+			tokens = append(tokens, queryToken{kind: tokenWord, key: strings.ToLower(key), text: value})
+		}
+	}
+	return tokens, nil
+}
+
+func indexRune(runes []rune, r rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func isQueryFilter(key string) bool {
+	switch key {
+	case "from", "in", "before", "after", "on", "has", "filename", "mentions", "domain", "replyto", "topic", "sort", "revisions":
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) peek() *queryToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+// parseAnd parses space separated expressions until the end or a closing parenthesis
+func (p *queryParser) parseAnd() (QueryNode, error) {
+	var nodes []QueryNode
+	for t := p.peek(); t != nil && t.kind != tokenClose; t = p.peek() {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	switch len(nodes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return nodes[0], nil
+	}
+	return AndNode{Nodes: nodes}, nil
+}
+
+func (p *queryParser) parseOr() (QueryNode, error) {
+	if t := p.peek(); t.kind == tokenOr {
+		return nil, errors.New("OR must be placed between two terms")
+	}
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []QueryNode{node}
+	for t := p.peek(); t != nil && t.kind == tokenOr; t = p.peek() {
+		p.pos++
+		if t := p.peek(); t == nil || t.kind == tokenOr || t.kind == tokenClose {
+			return nil, errors.New("OR must be placed between two terms")
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	for _, node := range nodes {
+		if node == nil {
+			return nil, errors.New("sort: and revisions: can not be combined with OR")
+		}
+	}
+	return OrNode{Nodes: nodes}, nil
+}
+
+func (p *queryParser) parseUnary() (QueryNode, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, errors.New("unexpected end of query")
+	}
+	if t.kind != tokenNot {
+		return p.parsePrimary()
+	}
+	p.pos++
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, errors.New("sort: and revisions: can not be excluded")
+	}
+	return NotNode{Node: node}, nil
+}
+
+func (p *queryParser) parsePrimary() (QueryNode, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, errors.New("unexpected end of query")
+	}
+	p.pos++
+	switch t.kind {
+	case tokenOpen:
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t == nil || t.kind != tokenClose {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		if node == nil {
+			return nil, errors.New("empty parentheses")
+		}
+		return node, nil
+	case tokenPhrase:
+		if strings.TrimSpace(t.text) == "" {
+			return nil, errors.New("empty quoted phrase")
+		}
+		return TermNode{Text: t.text, Phrase: true}, nil
+	case tokenWord:
+		if t.key == "" {
+			if len(t.text) > 1 && strings.HasPrefix(t.text, "#") {
+				return HashtagNode{Tag: t.text[1:]}, nil
+			}
+			return TermNode{Text: t.text}, nil
+		}
+		return p.parseFilter(t.key, t.text)
+	case tokenNot:
+		return nil, errors.New("unexpected -")
+	}
+	return nil, errors.New("unexpected closing parenthesis")
+}
+
+func (p *queryParser) parseFilter(key, value string) (QueryNode, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%s: requires a value", key)
+	}
+	switch key {
+	case "from":
+		value = strings.TrimPrefix(value, "@")
+		if peerId, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return FromNode{PeerId: peerId}, nil
+		}
+		return FromNode{Username: value}, nil
+	case "in":
+		if strings.HasPrefix(value, "@") {
+			return InNode{Username: value[1:]}, nil
+		}
+		if chatId, err := strconv.ParseInt(value, 10, 64); err == nil {
+			// accept the id from t.me/c/ links as well
+			if chatId > 0 {
+				chatId = convert2BotChatId(chatId)
+			}
+			return InNode{ChatId: chatId}, nil
+		}
+		return InNode{Title: value, Username: value}, nil
+	case "before", "after", "on":
+		t, err := time.ParseInLocation(time.DateOnly, value, p.loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expects a date like %s", key, time.DateOnly)
+		}
+		if key == "on" {
+			return AndNode{Nodes: []QueryNode{DateNode{Time: t}, DateNode{Before: true, Time: t.AddDate(0, 0, 1)}}}, nil
+		}
+		return DateNode{Before: key == "before", Time: t}, nil
+	case "has":
+		value = strings.ToLower(value)
+		if !slices.Contains(hasKinds, value) {
+			return nil, fmt.Errorf("has: expects one of %s", strings.Join(hasKinds, ", "))
+		}
+		return HasNode{Kind: value}, nil
+	case "filename":
+		return FileNameNode{Text: value}, nil
+	case "mentions":
+		value = strings.TrimPrefix(value, "@")
+		if peerId, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return MentionNode{PeerId: peerId}, nil
+		}
+		return MentionNode{Username: value}, nil
+	case "domain":
+		domain := urlDomain(value)
+		if domain == "" {
+			return nil, errors.New("domain: expects a domain like github.com")
+		}
+		return DomainNode{Domain: domain}, nil
+	case "replyto":
+		if chatId, username, msgId, ok := parseTelegramLink(value); ok {
+			return ReplyToNode{ChatId: chatId, ChatUsername: username, MsgId: msgId}, nil
+		}
+		if msgId, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return ReplyToNode{MsgId: msgId}, nil
+		}
+		if strings.HasPrefix(value, "@") {
+			return ReplyToNode{Username: value[1:]}, nil
+		}
+		return nil, errors.New("replyto: expects a message id, a message link or @username")
+	case "topic":
+		if threadId, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return TopicNode{ThreadId: threadId}, nil
+		}
+		return TopicNode{Name: value}, nil
+	case "sort":
+		value = strings.ToLower(value)
+		if value != SortOrderTime && value != SortOrderRelevance {
+			return nil, fmt.Errorf("sort: expects %s or %s", SortOrderTime, SortOrderRelevance)
+		}
+		p.query.SortOrder = value
+		return nil, nil
+	case "revisions":
+		if !strings.EqualFold(value, "all") {
+			return nil, errors.New("revisions: expects all")
+		}
+		p.query.Revisions = true
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown filter %s:", key)
+}
+
+func hasPositiveNode(node QueryNode) bool {
+	switch n := node.(type) {
+	case NotNode:
+		return false
+	case AndNode:
+		for _, child := range n.Nodes {
+			if hasPositiveNode(child) {
+				return true
+			}
+		}
+		return false
+	case OrNode:
+		for _, child := range n.Nodes {
+			if !hasPositiveNode(child) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+	tests := []struct {
+		query string
+		root  QueryNode
+	}{
+		{"", nil},
+		{"hello", TermNode{Text: "hello"}},
+		{"hello world", AndNode{Nodes: []QueryNode{TermNode{Text: "hello"}, TermNode{Text: "world"}}}},
+		{`"hello world"`, TermNode{Text: "hello world", Phrase: true}},
+		{`say "hello world" -bye`, AndNode{Nodes: []QueryNode{TermNode{Text: "say"}, TermNode{Text: "hello world", Phrase: true}, NotNode{Node: TermNode{Text: "bye"}}}}},
+		{`hello -"good bye"`, AndNode{Nodes: []QueryNode{TermNode{Text: "hello"}, NotNode{Node: TermNode{Text: "good bye", Phrase: true}}}}},
+		// a dash inside a word is no exclusion
+		{"e-mail", TermNode{Text: "e-mail"}},
+		{"hello OR hi", OrNode{Nodes: []QueryNode{TermNode{Text: "hello"}, TermNode{Text: "hi"}}}},
+		{"hello | hi", OrNode{Nodes: []QueryNode{TermNode{Text: "hello"}, TermNode{Text: "hi"}}}},
+		{"(hello OR hi) world", AndNode{Nodes: []QueryNode{OrNode{Nodes: []QueryNode{TermNode{Text: "hello"}, TermNode{Text: "hi"}}}, TermNode{Text: "world"}}}},
+		{"hello -(a OR b)", AndNode{Nodes: []QueryNode{TermNode{Text: "hello"}, NotNode{Node: OrNode{Nodes: []QueryNode{TermNode{Text: "a"}, TermNode{Text: "b"}}}}}}},
+		{"#golang", HashtagNode{Tag: "golang"}},
+		{"@alice hello", AndNode{Nodes: []QueryNode{FromNode{Username: "alice"}, TermNode{Text: "hello"}}}},
+		{"from:114514 hello", AndNode{Nodes: []QueryNode{FromNode{PeerId: 114514}, TermNode{Text: "hello"}}}},
+		{`in:"my chat" hello`, AndNode{Nodes: []QueryNode{InNode{Title: "my chat", Username: "my chat"}, TermNode{Text: "hello"}}}},
+		{"in:1234567890 hello", AndNode{Nodes: []QueryNode{InNode{ChatId: -1001234567890}, TermNode{Text: "hello"}}}},
+		{"in:@mygroup hello", AndNode{Nodes: []QueryNode{InNode{Username: "mygroup"}, TermNode{Text: "hello"}}}},
+		{"on:2024-03-10 hello", AndNode{Nodes: []QueryNode{AndNode{Nodes: []QueryNode{DateNode{Time: day}, DateNode{Before: true, Time: day.AddDate(0, 0, 1)}}}, TermNode{Text: "hello"}}}},
+		{"after:2024-03-10 hello", AndNode{Nodes: []QueryNode{DateNode{Time: day}, TermNode{Text: "hello"}}}},
+		{"before:2024-03-10 hello", AndNode{Nodes: []QueryNode{DateNode{Before: true, Time: day}, TermNode{Text: "hello"}}}},
+		{"HAS:Photo", HasNode{Kind: MediaPhoto}},
+		{"replyto:123", ReplyToNode{MsgId: 123}},
+		{"replyto:https://t.me/c/1234567890/123", ReplyToNode{ChatId: -1001234567890, MsgId: 123}},
+		{"replyto:https://t.me/c/1234567890/7/123", ReplyToNode{ChatId: -1001234567890, MsgId: 123}},
+		{"replyto:https://t.me/mygroup/123", ReplyToNode{ChatUsername: "mygroup", MsgId: 123}},
+		{"replyto:https://t.me/mygroup/7/123", ReplyToNode{ChatUsername: "mygroup", MsgId: 123}},
+		{"replyto:@alice", ReplyToNode{Username: "alice"}},
+		{"topic:123", TopicNode{ThreadId: 123}},
+		{`topic:"release notes"`, TopicNode{Name: "release notes"}},
+	}
+	for _, test := range tests {
+		query, err := ParseSearchQuery(test.query, loc)
+		if err != nil {
+			t.Errorf("ParseSearchQuery(%q): %v", test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(query.Root, test.root) {
+			t.Errorf("ParseSearchQuery(%q) = %#v, want %#v", test.query, query.Root, test.root)
+		}
+	}
+}
+
+func TestParseSearchQueryOptions(t *testing.T) {
+	query, err := ParseSearchQuery("hello sort:relevance revisions:all", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.SortOrder != SortOrderRelevance || !query.Revisions {
+		t.Errorf("got sort order %q and revisions %v", query.SortOrder, query.Revisions)
+	}
+	if !reflect.DeepEqual(query.Root, TermNode{Text: "hello"}) {
+		t.Errorf("got root %#v", query.Root)
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`"hello`, "unclosed quotation mark"},
+		{`""`, "empty quoted phrase"},
+		{"OR hello", "OR must be placed between two terms"},
+		{"hello OR", "OR must be placed between two terms"},
+		{"hello OR OR hi", "OR must be placed between two terms"},
+		{"(hello OR) hi", "OR must be placed between two terms"},
+		{"hello OR sort:time", "sort: and revisions: can not be combined with OR"},
+		{"hello -sort:time", "sort: and revisions: can not be excluded"},
+		{"-hello", "query can not consist of exclusions only"},
+		{"-hello OR -hi", "query can not consist of exclusions only"},
+		{"(hello", "missing closing parenthesis"},
+		{"hello)", "unexpected closing parenthesis"},
+		{"() hello", "empty parentheses"},
+		{"on:2024-13-01", "on: expects a date like 2006-01-02"},
+		{"before:yesterday", "before: expects a date like 2006-01-02"},
+		{"from: hello", "from: requires a value"},
+		{"has:pdf", "has: expects one of link, media, photo, video, animation, audio, voice, video_note, sticker, file"},
+		{"replyto:https://t.me/joinchat/abc", "replyto: expects a message id, a message link or @username"},
+		{"replyto:hello", "replyto: expects a message id, a message link or @username"},
+		{"sort:name", "sort: expects time or relevance"},
+	}
+	for _, test := range tests {
+		_, err := ParseSearchQuery(test.query, time.UTC)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("ParseSearchQuery(%q) error = %v, want %q", test.query, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/liuzl/cedar-go"
+	"github.com/liuzl/gocc"
+)
+
+// Segmenter splits normalized text into words, chinese text is segmented by
+// forward maximum matching against the cedar tries of the simplified phrase
+// dictionary of gocc and an optional user dictionary
+type Segmenter struct {
+	tries []*cedar.Cedar
+}
+
+// NewSegmenter loads dictionaryFile if set, one word per line with anything
+// after the first whitespace ignored so jieba style dictionaries can be used.
+// Changing the dictionary only affects messages indexed afterwards.
+func NewSegmenter(dictionaryFile string, normalize func(string) string) (*Segmenter, error) {
+	s2t, err := gocc.New("s2t")
+	if err != nil {
+		return nil, err
+	}
+	var tries []*cedar.Cedar
+	for _, group := range s2t.DictChains {
+		for i, file := range group.Files {
+			if strings.HasSuffix(file, "Phrases.txt") {
+				tries = append(tries, group.Dicts[i].Trie)
+			}
+		}
+	}
+
+	if dictionaryFile != "" {
+		f, err := os.Open(dictionaryFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		trie := cedar.New()
+		count := 0
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) <= 0 {
+				continue
+			}
+			word := normalize(fields[0])
+			if utf8.RuneCountInString(word) < 2 {
+				continue
+			}
+			if err := trie.Insert([]byte(word), count); err != nil {
+				return nil, err
+			}
+			count++
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		log.Printf("Loaded %d words from %s", count, dictionaryFile)
+		tries = append(tries, trie)
+	}
+
+	return &Segmenter{tries: tries}, nil
+}
+
+// Segment returns the words of text, punctuation and spaces are dropped
+func (s *Segmenter) Segment(text string) []string {
+	var words []string
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			words = append(words, text[start:end])
+			start = -1
+		}
+	}
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush(i)
+			n := s.longestWord(text[i:])
+			if n < size {
+				n = size
+			}
+			words = append(words, text[i:i+n])
+			i += n
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if start < 0 {
+				start = i
+			}
+			i += size
+		default:
+			flush(i)
+			i += size
+		}
+	}
+	flush(len(text))
+	return words
+}
+
+// longestWord returns the byte length of the longest dictionary word at the start of text
+func (s *Segmenter) longestWord(text string) int {
+	max := 0
+	for _, trie := range s.tries {
+		ids := trie.PrefixMatch([]byte(text), 0)
+		if len(ids) <= 0 {
+			continue
+		}
+		// matches are ordered from the shortest to the longest key
+		key, err := trie.Key(ids[len(ids)-1])
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if len(key) > max {
+			max = len(key)
+		}
+	}
+	return max
+}
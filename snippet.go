@@ -0,0 +1,203 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/clipperhouse/uax29/graphemes"
+)
+
+// matchRange is a highlighted byte range of a message text
+type matchRange struct {
+	start, end int
+}
+
+// Terms returns the text of the terms a result is matched by, excluded terms are left out
+func (q *SearchQuery) Terms() []string {
+	var terms []string
+	var walk func(node QueryNode)
+	walk = func(node QueryNode) {
+		switch n := node.(type) {
+		case TermNode:
+			terms = append(terms, n.Text)
+		case HashtagNode:
+			terms = append(terms, "#"+n.Tag)
+		case AndNode:
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case OrNode:
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		}
+	}
+	walk(q.Root)
+	return terms
+}
+
+// matchRanges finds the terms in text through the same normalization as the search index
+// and returns the sorted and merged byte ranges of the hits in the original text
+func (d *Database) matchRanges(text string, terms []string) []matchRange {
+	if len(terms) <= 0 {
+		return nil
+	}
+	runes := []rune(text)
+	offsets := make([]int, 0, len(runes)+1)
+	offset := 0
+	for _, r := range runes {
+		offsets = append(offsets, offset)
+		offset += len(string(r))
+	}
+	offsets = append(offsets, offset)
+
+	// normalized runes map back to the original rune they came from, the whole text is normalized
+	// at once so phrase conversions apply and only falls back to single runes if the length changed
+	normalized := []rune(d.normalize(text))
+	origin := make([]int, 0, len(runes))
+	if len(normalized) == len(runes) {
+		for i := range runes {
+			origin = append(origin, i)
+		}
+	} else {
+		normalized = normalized[:0]
+		for i, r := range runes {
+			for _, n := range d.normalize(string(r)) {
+				normalized = append(normalized, n)
+				origin = append(origin, i)
+			}
+		}
+	}
+
+	var ranges []matchRange
+	for _, term := range terms {
+		needle := []rune(d.normalize(strings.TrimSpace(term)))
+		if len(needle) <= 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(normalized); i++ {
+			if !hasRunePrefix(normalized[i:], needle) {
+				continue
+			}
+			ranges = append(ranges, matchRange{start: offsets[origin[i]], end: offsets[origin[i+len(needle)-1]+1]})
+			i += len(needle) - 1
+		}
+	}
+	return mergeRanges(ranges)
+}
+
+func hasRunePrefix(s, prefix []rune) bool {
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeRanges(ranges []matchRange) []matchRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+	var merged []matchRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.start <= merged[n-1].end {
+			if r.end > merged[n-1].end {
+				merged[n-1].end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// snippetWindow returns the byte range of a snippet of text of at most size UTF-16 code units, the unit
+// of Telegram length limits, on grapheme boundaries and centered on the first match
+func snippetWindow(text string, ranges []matchRange, size int) (int, int, error) {
+	type boundary struct {
+		bytes, units int
+	}
+	bounds := []boundary{{0, 0}}
+	segments := graphemes.NewSegmenter([]byte(text))
+	for segments.Next() {
+		last := bounds[len(bounds)-1]
+		grapheme := segments.Bytes()
+		bounds = append(bounds, boundary{last.bytes + len(grapheme), last.units + utf16Len(string(grapheme))})
+	}
+	if err := segments.Err(); err != nil {
+		return 0, 0, err
+	}
+	total := bounds[len(bounds)-1].units
+	if total <= size {
+		return 0, len(text), nil
+	}
+	// index of the last boundary at or before a byte offset or a number of units
+	byBytes := func(offset int) int {
+		return sort.Search(len(bounds), func(i int) bool { return bounds[i].bytes > offset }) - 1
+	}
+	byUnits := func(units int) int {
+		return sort.Search(len(bounds), func(i int) bool { return bounds[i].units > units }) - 1
+	}
+
+	start := 0
+	if len(ranges) > 0 {
+		matchStart := bounds[byBytes(ranges[0].start)].units
+		match := bounds[byBytes(ranges[0].end)].units - matchStart
+		if match < size {
+			start = matchStart - (size-match)/2
+		} else {
+			start = matchStart
+		}
+		start = max(min(start, total-size), 0)
+	}
+	// the start is rounded up to a grapheme boundary, rounding down could cut the end of the match
+	i := byUnits(start)
+	if bounds[i].units < start {
+		i++
+	}
+	j := byUnits(bounds[i].units + size)
+	if j <= i {
+		j = i + 1
+	}
+	return bounds[i].bytes, bounds[j].bytes, nil
+}
+
+// snippetAround trims text to about size UTF-16 code units centered on the first match,
+// ellipses mark trimmed sides and the ranges are shifted into the snippet
+func snippetAround(text string, ranges []matchRange, size int) (string, []matchRange, error) {
+	start, end, err := snippetWindow(text, ranges, size)
+	if err != nil {
+		return "", nil, err
+	}
+	var prefix, suffix string
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(text) {
+		suffix = "…"
+	}
+	var shifted []matchRange
+	for _, r := range ranges {
+		if r.end <= start || r.start >= end {
+			continue
+		}
+		r.start = max(r.start, start) - start + len(prefix)
+		r.end = min(r.end, end) - start + len(prefix)
+		shifted = append(shifted, r)
+	}
+	return prefix + text[start:end] + suffix, shifted, nil
+}
+
+// highlightPlain wraps the ranges of text in brackets for plain text like result titles
+func highlightPlain(text string, ranges []matchRange) string {
+	var sb strings.Builder
+	last := 0
+	for _, r := range ranges {
+		sb.WriteString(text[last:r.start])
+		sb.WriteString("[" + text[r.start:r.end] + "]")
+		last = r.end
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
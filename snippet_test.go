@@ -0,0 +1,111 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rangeOf returns the byte range of the first occurrence of sub in text
+func rangeOf(text, sub string) matchRange {
+	start := strings.Index(text, sub)
+	return matchRange{start: start, end: start + len(sub)}
+}
+
+func TestMergeRanges(t *testing.T) {
+	got := mergeRanges([]matchRange{{10, 12}, {0, 3}, {2, 5}, {5, 6}, {11, 11}})
+	want := []matchRange{{0, 6}, {10, 12}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeRanges = %v, want %v", got, want)
+	}
+}
+
+func TestSnippetAround(t *testing.T) {
+	tests := []struct {
+		text   string
+		match  string
+		size   int
+		want   string
+		ranges []string
+	}{
+		{"hello world", "world", 64, "hello world", []string{"world"}},
+		{"abc hello def", "hello", 5, "…hello…", []string{"hello"}},
+		{"abc hello def", "hello", 7, "… hello …", []string{"hello"}},
+		// a match longer than the snippet starts it and is cut
+		{"abc hello def", "hello", 3, "…hel…", []string{"hel"}},
+		{"hello world and more", "hello", 5, "hello…", []string{"hello"}},
+		{"more and hello world", "world", 7, "…o world", []string{"world"}},
+		// no match starts at the beginning
+		{"hello world", "", 5, "hello…", nil},
+	}
+	for _, test := range tests {
+		var ranges []matchRange
+		if test.match != "" {
+			ranges = []matchRange{rangeOf(test.text, test.match)}
+		}
+		snippet, shifted, err := snippetAround(test.text, ranges, test.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snippet != test.want {
+			t.Errorf("snippetAround(%q, %q, %d) = %q, want %q", test.text, test.match, test.size, snippet, test.want)
+			continue
+		}
+		var got []string
+		for _, r := range shifted {
+			got = append(got, snippet[r.start:r.end])
+		}
+		if !reflect.DeepEqual(got, test.ranges) {
+			t.Errorf("snippetAround(%q, %q, %d) ranges = %q, want %q", test.text, test.match, test.size, got, test.ranges)
+		}
+	}
+}
+
+func TestHighlightPlain(t *testing.T) {
+	text := "say hello to the world"
+	got := highlightPlain(text, []matchRange{rangeOf(text, "hello"), rangeOf(text, "world")})
+	if want := "say [hello] to the [world]"; got != want {
+		t.Errorf("highlightPlain = %q, want %q", got, want)
+	}
+}
+
+func TestSnippetWindow(t *testing.T) {
+	emoji := strings.Repeat("😀", 10)
+	thumbs := strings.Repeat("👍🏽", 3)
+	tests := []struct {
+		text  string
+		match string
+		size  int
+		want  string
+	}{
+		{"short", "", 64, "short"},
+		// emoji are 2 UTF-16 code units
+		{emoji + "match" + emoji, "match", 9, "😀match😀"},
+		// an emoji which does not fit is left out instead of split into halves
+		{emoji + "match" + emoji, "match", 8, "match😀"},
+		{emoji + "match" + emoji, "", 5, "😀😀"},
+		{emoji + "match", "match", 8, "😀match"},
+		// grapheme clusters are not split
+		{thumbs + "abc" + thumbs, "abc", 7, "abc👍🏽"},
+		{thumbs + "abc" + thumbs, "abc", 10, "abc👍🏽"},
+		{thumbs + "abc" + thumbs, "abc", 11, "👍🏽abc👍🏽"},
+		{"👨‍👩‍👧‍👦 family", "family", 8, " family"},
+	}
+	for _, test := range tests {
+		var ranges []matchRange
+		if test.match != "" {
+			ranges = []matchRange{rangeOf(test.text, test.match)}
+		}
+		start, end, err := snippetWindow(test.text, ranges, test.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := test.text[start:end]
+		if got != test.want {
+			t.Errorf("snippetWindow(%q, %q, %d) = %q, want %q", test.text, test.match, test.size, got, test.want)
+		}
+		if utf16Len(got) > test.size {
+			t.Errorf("snippetWindow(%q, %q, %d) = %q is %d UTF-16 code units long", test.text, test.match, test.size, got, utf16Len(got))
+		}
+	}
+}
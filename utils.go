@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
-
-	"github.com/clipperhouse/uax29/graphemes"
 )
 
 var allMdV2 = []string{"_", "*", "[", "]", "(", ")", "~", "`", ">", "#", "+", "-", "=", "|", "{", "}", ".", "!"}
@@ -35,12 +35,47 @@ func text2ExpandableQuote(text string) string {
 	return result
 }
 
-func generateTelegramLink(chatId, msgId int64) string {
-	return fmt.Sprintf("https://t.me/c/%s/%d", convert2NativeChatId(chatId), msgId)
+// generateTelegramLink returns the link of a message, public chats are linked by their username so
+// the link also works for non-members, messages in forum topics are linked within their topic and
+// threadId is 0 for other messages
+func generateTelegramLink(username string, chatId, threadId, msgId int64) string {
+	chat := "c/" + convert2NativeChatId(chatId)
+	if username != "" {
+		chat = username
+	}
+	if threadId != 0 {
+		return fmt.Sprintf("https://t.me/%s/%d/%d", chat, threadId, msgId)
+	}
+	return fmt.Sprintf("https://t.me/%s/%d", chat, msgId)
 }
 
-func text2Via(text string, chatId int64, msgId int64, fullname string) string {
-	return fmt.Sprintf("%s\n[Via %s](%s)", text, escapeMarkdownV2(fullname), generateTelegramLink(chatId, msgId))
+func text2Via(text string, username string, chatId int64, threadId int64, msgId int64, fullname string) string {
+	return fmt.Sprintf("%s\n[Via %s](%s)", text, escapeMarkdownV2(fullname), generateTelegramLink(username, chatId, threadId, msgId))
+}
+
+// links to messages in forum topics have the thread id between the chat and message id,
+// usernames start with a letter and have at least 4 characters so they never match c/
+var telegramLinkRe = regexp.MustCompile(`https://t\.me/(?:c/(\d+)|([A-Za-z]\w{3,31}))/(?:\d+/)?(\d+)`)
+
+// parseTelegramLink returns the message id of the first message link in text and either the bot chat id
+// of a private link or the chat username of a public link
+func parseTelegramLink(text string) (chatId int64, username string, msgId int64, ok bool) {
+	matches := telegramLinkRe.FindStringSubmatch(text)
+	if len(matches) != 4 {
+		return 0, "", 0, false
+	}
+	msgId, err := strconv.ParseInt(matches[3], 10, 64)
+	if err != nil {
+		return 0, "", 0, false
+	}
+	if matches[2] != "" {
+		return 0, matches[2], msgId, true
+	}
+	chatId, err = strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, "", 0, false
+	}
+	return convert2BotChatId(chatId), "", msgId, true
 }
 
 func convert2NativeChatId(chatId int64) string {
@@ -57,31 +92,26 @@ func convert2BotChatId(chatId int64) int64 {
 	return newChatId
 }
 
-func removeDuplicate[T comparable](sliceList []T) []T {
-	allKeys := make(map[T]bool)
-	list := []T{}
-	for _, item := range sliceList {
-		if _, value := allKeys[item]; !value {
-			allKeys[item] = true
-			list = append(list, item)
-		}
+// urlDomain returns the lower cased host of a link without www., links may omit the scheme
+func urlDomain(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
 	}
-	return list
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
 }
 
-func trimUnicodeAddEllipsis(text string, l int) (string, error) {
-	var newByteText []byte
-	byteText := []byte(text)
-	segments := graphemes.NewSegmenter(byteText)
-	for segments.Next() && len(newByteText) <= l {
-		newByteText = append(newByteText, segments.Bytes()...)
-	}
-	if err := segments.Err(); err != nil {
-		return "", err
-	}
-	newText := string(newByteText)
-	if len(text) > len(newText) {
-		return newText + "…", nil
-	}
-	return newText, nil
+var likeRepl = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes LIKE wildcards, the pattern must be used with ESCAPE '\'
+func escapeLike(s string) string {
+	return likeRepl.Replace(s)
+}
+
+// ftsPhrase quotes text as a FTS5 string so operators and punctuation are matched literally
+func ftsPhrase(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
 }
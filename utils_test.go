@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestParseTelegramLink(t *testing.T) {
+	tests := []struct {
+		text     string
+		chatId   int64
+		username string
+		msgId    int64
+		ok       bool
+	}{
+		{"https://t.me/c/1234567890/42", -1001234567890, "", 42, true},
+		{"https://t.me/c/1234567890/7/42", -1001234567890, "", 42, true},
+		{"https://t.me/mygroup/42", 0, "mygroup", 42, true},
+		{"https://t.me/mygroup/7/42", 0, "mygroup", 42, true},
+		{"see https://t.me/mygroup/42 for details", 0, "mygroup", 42, true},
+		// usernames have at least 4 characters and start with a letter
+		{"https://t.me/abc/42", 0, "", 0, false},
+		{"https://t.me/1group/42", 0, "", 0, false},
+		{"https://t.me/joinchat/abcdef", 0, "", 0, false},
+		{"https://t.me/mygroup", 0, "", 0, false},
+		{"https://t.me/c/1234567890", 0, "", 0, false},
+		{"hello", 0, "", 0, false},
+	}
+	for _, test := range tests {
+		chatId, username, msgId, ok := parseTelegramLink(test.text)
+		if chatId != test.chatId || username != test.username || msgId != test.msgId || ok != test.ok {
+			t.Errorf("parseTelegramLink(%q) = %d, %q, %d, %v, want %d, %q, %d, %v", test.text, chatId, username, msgId, ok, test.chatId, test.username, test.msgId, test.ok)
+		}
+	}
+}